@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/AlfonsSkills/SkillSync/internal/skill"
+	"github.com/AlfonsSkills/SkillSync/internal/target"
+)
+
+// categoryOptions 有分类子目录的工具（如 Codex）的全局安装位置
+type categoryOptions struct {
+	category       string // 指定分类，为空时沿用已安装副本所在分类，否则使用默认安装目录
+	allowProtected bool   // 允许写入只读分类（如 Codex 的 .system）
+}
+
+// installedCopy 已安装 skill 的一个副本
+type installedCopy struct {
+	path     string
+	category string // 所在分类，不在分类子目录中时为空
+}
+
+// findInstalledSkill 在工具的全局目录中查找已安装 skill 的所有副本
+// 有分类的工具依次搜索各分类子目录与 skills 根目录，同一 skill 可能存在于多个分类
+// 返回: 按搜索顺序排列的副本，未找到时为空
+func findInstalledSkill(p target.ToolProvider, skillName string) []installedCopy {
+	var copies []installedCopy
+	seen := make(map[string]bool)
+	add := func(path, category string) {
+		if path != "" && !seen[path] {
+			seen[path] = true
+			copies = append(copies, installedCopy{path: path, category: category})
+		}
+	}
+
+	categories := p.Categories()
+	for _, category := range categories {
+		dir, err := target.CategoryDir(p, category)
+		if err != nil {
+			continue
+		}
+		for _, path := range skill.FindSkillDirs(dir, skillName) {
+			add(path, category)
+		}
+	}
+
+	dirs := []string{}
+	if dir, err := p.GlobalInstallDir(); err == nil {
+		dirs = append(dirs, dir)
+	}
+	if len(categories) > 0 {
+		if dir, err := p.GlobalSkillsDir(); err == nil {
+			dirs = append(dirs, dir)
+		}
+	}
+	for _, dir := range dirs {
+		for _, path := range skill.FindSkillDirs(dir, skillName) {
+			// 根目录下的分类子目录不是 skill
+			if !slices.Contains(categories, filepath.Base(path)) {
+				add(path, "")
+			}
+		}
+	}
+	return copies
+}
+
+// categoryInstallDir 返回 skill 在有分类工具中的全局安装目录
+// 指定分类时安装到该分类；已安装在某分类时原地更新（优先可写的分类）；只读分类需显式允许
+// 返回: 安装目录，使用工具默认安装目录时为空
+func categoryInstallDir(p target.ToolProvider, skillName string, opts categoryOptions) (string, error) {
+	if len(p.Categories()) == 0 {
+		return "", nil
+	}
+
+	if opts.category != "" {
+		dir, err := target.CategoryDir(p, opts.category)
+		if err != nil {
+			return "", err
+		}
+		if target.IsProtectedCategory(p, opts.category) && !opts.allowProtected {
+			return "", fmt.Errorf("category %s is read-only, use --allow-protected to write to it", opts.category)
+		}
+		return dir, nil
+	}
+
+	protected := ""
+	for _, c := range findInstalledSkill(p, skillName) {
+		if c.category == "" {
+			continue
+		}
+		if target.IsProtectedCategory(p, c.category) && !opts.allowProtected {
+			if protected == "" {
+				protected = c.category
+			}
+			continue
+		}
+		return filepath.Dir(c.path), nil
+	}
+	if protected != "" {
+		return "", fmt.Errorf("already installed in read-only category %s, use --category to install elsewhere or --allow-protected to overwrite", protected)
+	}
+	return "", nil
+}
+
+// checkCategoryFlag 校验 --category：至少一个目标工具支持该分类
+// 无分类的工具忽略该参数
+func checkCategoryFlag(providers []target.ToolProvider, category string) error {
+	if category == "" {
+		return nil
+	}
+	supported := false
+	for _, p := range providers {
+		if len(p.Categories()) == 0 {
+			continue
+		}
+		if _, err := target.CategoryDir(p, category); err != nil {
+			return err
+		}
+		supported = true
+	}
+	if !supported {
+		return fmt.Errorf("--category %s: none of the target tools have categories", category)
+	}
+	return nil
+}
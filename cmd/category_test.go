@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+
+	"github.com/AlfonsSkills/SkillSync/internal/target"
+)
+
+// newCodexHome 创建临时 Codex 配置目录，并在给定分类（"" 表示 skills 根目录）中安装 skill
+func newCodexHome(t *testing.T, name string, categories ...string) (target.ToolProvider, string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("CODEX_HOME", home)
+	root := filepath.Join(home, "skills")
+	for _, category := range categories {
+		dir := filepath.Join(root, category, name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: "+name+"\n---\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return target.NewCodexProvider(), root
+}
+
+func TestFindInstalledSkillAllCategories(t *testing.T) {
+	p, root := newCodexHome(t, "demo", "public", ".system", "")
+
+	want := []installedCopy{
+		{path: filepath.Join(root, "public", "demo"), category: "public"},
+		{path: filepath.Join(root, ".system", "demo"), category: ".system"},
+		{path: filepath.Join(root, "demo")},
+	}
+	if got := findInstalledSkill(p, "demo"); !reflect.DeepEqual(got, want) {
+		t.Errorf("findInstalledSkill() = %v, want %v", got, want)
+	}
+	if got := findInstalledSkill(p, "missing"); len(got) != 0 {
+		t.Errorf("findInstalledSkill(missing) = %v, want none", got)
+	}
+}
+
+func TestRemoveFromProviderAllCategories(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowProtected bool
+		wantErrs       []bool // 按 public、.system、根目录顺序
+	}{
+		{"protected copy is kept", false, []bool{false, true, false}},
+		{"allow protected removes every copy", true, []bool{false, false, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, root := newCodexHome(t, "demo", "public", ".system", "")
+
+			results := removeFromProvider(p, "demo", "", tt.allowProtected)
+			if len(results) != len(tt.wantErrs) {
+				t.Fatalf("removeFromProvider() returned %d results, want %d", len(results), len(tt.wantErrs))
+			}
+			for i, r := range results {
+				if (r.err != nil) != tt.wantErrs[i] {
+					t.Errorf("result %d (%q) error = %v, want error %v", i, r.category, r.err, tt.wantErrs[i])
+				}
+			}
+
+			_, err := os.Stat(filepath.Join(root, ".system", "demo"))
+			if kept := err == nil; kept == tt.allowProtected {
+				t.Errorf(".system copy kept = %v, want %v", kept, !tt.allowProtected)
+			}
+			for _, dir := range []string{filepath.Join(root, "public", "demo"), filepath.Join(root, "demo")} {
+				if _, err := os.Stat(dir); !os.IsNotExist(err) {
+					t.Errorf("%s was not removed", dir)
+				}
+			}
+		})
+	}
+}
+
+func TestCategoryInstallDir(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []string
+		opts       categoryOptions
+		want       string // 相对 skills 根目录，"-" 表示使用默认安装目录
+		wantErr    bool
+	}{
+		{"not installed", nil, categoryOptions{}, "-", false},
+		{"writable category preferred", []string{".system", "public"}, categoryOptions{}, "public", false},
+		{"only in protected category", []string{".system"}, categoryOptions{}, "", true},
+		{"protected allowed", []string{".system"}, categoryOptions{allowProtected: true}, ".system", false},
+		{"explicit category", []string{".system"}, categoryOptions{category: "public"}, "public", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, root := newCodexHome(t, "demo", tt.categories...)
+			got, err := categoryInstallDir(p, "demo", tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("categoryInstallDir() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("categoryInstallDir() error = %v", err)
+			}
+			want := ""
+			if tt.want != "-" {
+				want = filepath.Join(root, tt.want)
+			}
+			if got != want {
+				t.Errorf("categoryInstallDir() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestRemoveFromProviderRenamedCopies(t *testing.T) {
+	projectRoot := t.TempDir()
+	p, err := target.NewCustomProvider(target.CustomSpec{ID: "test-tool", GlobalDir: t.TempDir(), LocalDir: ".test/skills"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	localDir := p.LocalSkillsDir(projectRoot)
+	for _, dir := range []string{"demo", "old-demo"} {
+		writeTestSkill(t, filepath.Join(localDir, dir), "demo")
+	}
+
+	results := removeFromProvider(p, "demo", projectRoot, false)
+	if len(results) != 2 {
+		t.Fatalf("removeFromProvider() returned %d results, want 2", len(results))
+	}
+	if got := dirEntryNames(localDir); got != nil {
+		t.Errorf("remaining entries = %v, want none", got)
+	}
+}
+
+// dirEntryNames 返回目录中剩余的条目名称
+func dirEntryNames(dir string) []string {
+	entries, _ := os.ReadDir(dir)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestEnsureSkillParentDir(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []string
+		want       string // 相对 skills 根目录
+		wantErr    bool
+	}{
+		{"new skill goes to the install dir", nil, "public", false},
+		{"root copy does not change the install dir", []string{""}, "public", false},
+		{"protected copy is not linked over", []string{".system"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, root := newCodexHome(t, "demo", tt.categories...)
+			got, err := ensureSkillParentDir(p, "demo", categoryOptions{}, "")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ensureSkillParentDir() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ensureSkillParentDir() error = %v", err)
+			}
+			if want := filepath.Join(root, tt.want); got != want {
+				t.Errorf("ensureSkillParentDir() = %q, want %q", got, want)
+			}
+			if info, err := os.Stat(got); err != nil || !info.IsDir() {
+				t.Errorf("directory %s was not created", got)
+			}
+		})
+	}
+
+	projectRoot := t.TempDir()
+	p, _ := newCodexHome(t, "demo")
+	got, err := ensureSkillParentDir(p, "demo", categoryOptions{}, projectRoot)
+	if err != nil || got != p.LocalSkillsDir(projectRoot) {
+		t.Errorf("ensureSkillParentDir(project) = %q, %v, want %q", got, err, p.LocalSkillsDir(projectRoot))
+	}
+}
+
+func TestShowRemovePreviewProtected(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowProtected bool
+		wantKept       bool
+	}{
+		{"protected copy is kept", false, true},
+		{"allow protected lists it for removal", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := newCodexHome(t, "demo", ".system")
+
+			// 捕获预览输出
+			var buf bytes.Buffer
+			output, noColor := color.Output, color.NoColor
+			color.Output, color.NoColor = &buf, true
+			t.Cleanup(func() { color.Output, color.NoColor = output, noColor })
+
+			showRemovePreview("demo", []target.ToolProvider{p}, true, false, "", tt.allowProtected)
+			if kept := strings.Contains(buf.String(), "read-only, kept"); kept != tt.wantKept {
+				t.Errorf("preview shows copy as kept = %v, want %v\n%s", kept, tt.wantKept, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/fatih/color"
+
+	"github.com/AlfonsSkills/SkillSync/internal/git"
+)
+
+// gitSourceLoader 基于 git.Fetcher 实现 skill.SourceLoader
+// 同一仓库 + ref 只拉取一次，临时目录在 Cleanup 时统一清理
+type gitSourceLoader struct {
+	fetcher  *git.Fetcher
+	verifier *sourceVerifier
+	dirs     map[string]string // key: repoKey@ref -> 本地目录
+	owned    []string          // 由 loader 创建、需要清理的目录
+}
+
+// newGitSourceLoader 创建加载器，并登记已拉取的根仓库避免重复 clone
+func newGitSourceLoader(fetcher *git.Fetcher, verifier *sourceVerifier, rootSource, rootRef, rootDir string) *gitSourceLoader {
+	l := &gitSourceLoader{
+		fetcher:  fetcher,
+		verifier: verifier,
+		dirs:     make(map[string]string),
+	}
+	l.dirs[l.Key(rootSource)+"@"+rootRef] = rootDir
+	return l
+}
+
+// Key 返回仓库规范化标识，无法解析时使用原始输入
+func (l *gitSourceLoader) Key(source string) string {
+	if key, err := l.fetcher.RepoKey(source); err == nil {
+		return key
+	}
+	return source
+}
+
+// Load 拉取仓库到临时目录（带缓存）
+func (l *gitSourceLoader) Load(source, ref string) (string, error) {
+	cacheKey := l.Key(source) + "@" + ref
+	if dir, ok := l.dirs[cacheKey]; ok {
+		return dir, nil
+	}
+
+	var dir string
+	var err error
+	if ref != "" {
+		color.Cyan("📦 Fetching dependency source: %s (ref: %s)\n", source, ref)
+		dir, err = l.fetcher.CloneToTempWithBranch(source, ref)
+	} else {
+		color.Cyan("📦 Fetching dependency source: %s\n", source)
+		dir, err = l.fetcher.CloneToTemp(source)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	l.dirs[cacheKey] = dir
+	l.owned = append(l.owned, dir)
+
+	// 依赖来源同样按策略校验签名
+	if err := l.verifier.checkGit(source, ref, dir); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// Cleanup 删除加载器创建的临时目录
+func (l *gitSourceLoader) Cleanup() {
+	for _, dir := range l.owned {
+		os.RemoveAll(dir)
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/AlfonsSkills/SkillSync/internal/target"
+)
+
+// envCmd env command
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Show resolved skill directories and where they come from",
+	Long: `Show each tool's skill directories as SkillSync resolves them.
+
+Directories follow the same environment variables the tools read:
+CLAUDE_CONFIG_DIR (Claude Code), CODEX_HOME (Codex CLI), CRUSH_SKILLS_DIR (Crush)
+and XDG_CONFIG_HOME (Crush, OpenCode, Goose, Amp, agents). Each tool shows whether its directories
+come from one of these variables, from config.yaml, from a provider plugin or from
+the built-in default.
+
+Examples:
+  skillsync env
+  skillsync env --target claude,codex`,
+	Args: cobra.NoArgs,
+	RunE: runEnv,
+}
+
+func init() {
+	rootCmd.AddCommand(envCmd)
+}
+
+func runEnv(cmd *cobra.Command, args []string) error {
+	providers, err := target.ParseProviders(targetFlags)
+	if err != nil {
+		return err
+	}
+
+	color.Cyan("🌱 Environment:\n")
+	for _, name := range target.DirEnvVars {
+		if v := os.Getenv(name); v != "" {
+			color.White("   %s=%s\n", name, v)
+		} else {
+			color.HiBlack("   %s (unset)\n", name)
+		}
+	}
+	fmt.Println()
+
+	// 不在项目中时项目级目录显示为相对路径
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		projectRoot = "."
+	}
+
+	color.Cyan("📁 Skill directories:\n\n")
+	for _, p := range providers {
+		color.White("  %s %s\n", color.New(color.Bold).Sprint(p.DisplayName()), color.HiBlackString("(%s)", target.DirSource(p)))
+		globalDir, err := p.GlobalSkillsDir()
+		if err != nil {
+			color.Yellow("    ⚠ %v\n", err)
+		} else {
+			color.White("    Global:  %s\n", globalDir)
+		}
+		if installDir, err := p.GlobalInstallDir(); err == nil && installDir != globalDir {
+			color.White("    Install: %s\n", installDir)
+		}
+		if localDir := p.LocalSkillsDir(projectRoot); localDir != "" {
+			color.White("    Project: %s\n", localDir)
+		}
+	}
+	return nil
+}
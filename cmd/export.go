@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/AlfonsSkills/SkillSync/internal/export"
+	"github.com/AlfonsSkills/SkillSync/internal/git"
+	"github.com/AlfonsSkills/SkillSync/internal/skill"
+	"github.com/AlfonsSkills/SkillSync/internal/target"
+)
+
+var (
+	exportFormat string
+	exportOutput string
+	exportSkills []string
+	exportName   string
+	exportOwner  string
+	exportBundle string
+)
+
+// 导出格式
+const (
+	exportClaudePlugin = "claude-plugin"
+	exportCursorRules  = "cursor-rules"
+)
+
+// exportCmd export command
+var exportCmd = &cobra.Command{
+	Use:   "export [source]",
+	Short: "Export skills to another tool's native format",
+	Long: `Export installed or fetched skills to another tool's native format.
+
+Source can be a repository (same formats as install), a local directory or a
+skill archive. Without a source, globally installed skills are exported
+(filtered by --target).
+
+Formats:
+  claude-plugin   Claude Code plugin marketplace:
+                    .claude-plugin/marketplace.json
+                    plugins/<plugin>/.claude-plugin/plugin.json
+                    plugins/<plugin>/skills/<skill>/SKILL.md
+  cursor-rules    Cursor project rules (.cursor/rules/<skill>.mdc)
+
+Examples:
+  skillsync export --format claude-plugin -o ./marketplace
+  skillsync export AlfonsSkills/skills --format claude-plugin -o ./marketplace --bundle team-skills
+  skillsync export ./skills --format cursor-rules -o . --skill code-review`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExport,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", exportClaudePlugin, "Export format (claude-plugin, cursor-rules)")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", ".", "Output directory")
+	exportCmd.Flags().StringSliceVarP(&exportSkills, "skill", "s", nil, "Only export these skills (default: all)")
+	exportCmd.Flags().StringVar(&exportName, "name", "", "Marketplace name (default: output directory name)")
+	exportCmd.Flags().StringVar(&exportOwner, "owner", "", "Marketplace owner (default: git user.name)")
+	exportCmd.Flags().StringVar(&exportBundle, "bundle", "", "Bundle all skills into a single plugin with this name")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	if exportFormat != exportClaudePlugin && exportFormat != exportCursorRules {
+		return fmt.Errorf("unknown format: %s (supported: %s, %s)", exportFormat, exportClaudePlugin, exportCursorRules)
+	}
+	if exportBundle != "" {
+		if err := skill.ValidateName(exportBundle); err != nil {
+			return fmt.Errorf("invalid bundle name: %w", err)
+		}
+	}
+
+	var skills []skill.SkillInfo
+	if len(args) == 0 {
+		var err error
+		if skills, err = installedSkillInfos(targetFlags); err != nil {
+			return err
+		}
+	} else {
+		dir, rootName, cleanup, err := fetchSkillSource(args[0])
+		if err != nil {
+			color.Red("❌ %v\n", err)
+			return err
+		}
+		defer cleanup()
+		if skills, err = sourceSkills(dir, rootName); err != nil {
+			color.Red("❌ %v\n", err)
+			return err
+		}
+	}
+
+	// 按 --skill 过滤
+	if len(exportSkills) > 0 {
+		var filtered []skill.SkillInfo
+		for _, s := range skills {
+			if slices.Contains(exportSkills, s.Name) {
+				filtered = append(filtered, s)
+			}
+		}
+		for _, name := range exportSkills {
+			if !slices.ContainsFunc(filtered, func(s skill.SkillInfo) bool { return s.Name == name }) {
+				color.Yellow("⚠ Skill not found: %s\n", name)
+			}
+		}
+		skills = filtered
+	}
+	if len(skills) == 0 {
+		color.Yellow("⚠ No skills to export\n")
+		return nil
+	}
+
+	outDir, err := filepath.Abs(exportOutput)
+	if err != nil {
+		return err
+	}
+	copyOpts := skill.DefaultCopyOptions()
+
+	color.Cyan("📤 Exporting %d skill(s) as %s\n", len(skills), exportFormat)
+	switch exportFormat {
+	case exportClaudePlugin:
+		market, err := export.ExportClaudePlugin(skills, outDir, export.ClaudePluginOptions{
+			Name:         exportName,
+			Owner:        exportOwner,
+			DefaultOwner: gitUserName(),
+			Bundle:       exportBundle,
+			Copy:         copyOpts,
+		})
+		if err != nil {
+			color.Red("❌ Export failed: %v\n", err)
+			return err
+		}
+		for _, p := range market.Plugins {
+			color.White("   • %s → %v\n", color.New(color.FgCyan).Sprint(p.Name), p.Source)
+		}
+		color.Green("\n✅ Marketplace: %s\n", filepath.Join(outDir, skill.PluginManifestDir, skill.MarketplaceFile))
+		color.HiCyan("   Add it in Claude Code with: /plugin marketplace add %s\n", outDir)
+	case exportCursorRules:
+		rulesDir := target.NewCursorProvider().(target.RulesProvider).LocalRulesDir(outDir)
+		for _, s := range skills {
+			opts, err := copyOpts.ForSkill(s.Path)
+			if err != nil {
+				return err
+			}
+			rulePath, err := export.ExportCursorRule(s, rulesDir, opts)
+			if err != nil {
+				color.Red("❌ Export %s failed: %v\n", s.Name, err)
+				return err
+			}
+			color.Green("   ✓ %s\n", rulePath)
+		}
+	}
+	return nil
+}
+
+// installedSkillInfos 收集全局已安装的 skill（同名仅保留第一个）
+func installedSkillInfos(targets []string) ([]skill.SkillInfo, error) {
+	providers, err := target.ParseProviders(targets)
+	if err != nil {
+		return nil, err
+	}
+	var skills []skill.SkillInfo
+	seen := make(map[string]bool)
+	for _, p := range providers {
+		locals, err := scanLocalSkillsWithProvider(p)
+		if err != nil {
+			continue
+		}
+		for _, ls := range locals {
+			if !ls.Valid || seen[ls.Name] {
+				continue
+			}
+			seen[ls.Name] = true
+			skills = append(skills, skill.LoadSkillInfo(ls.Path, ls.DirName))
+		}
+	}
+	return skills, nil
+}
+
+// fetchSkillSource 获取 skill 来源目录
+// 支持本地目录、skill 归档与 git 仓库（含 tree URL）
+// 返回: 来源目录、来源本身是 skill 时的回退名称、清理函数
+func fetchSkillSource(source string) (string, string, func(), error) {
+	noop := func() {}
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		abs, err := filepath.Abs(source)
+		if err != nil {
+			return "", "", noop, err
+		}
+		return abs, filepath.Base(abs), noop, nil
+	}
+	if isArchiveSource(source) {
+		dir, err := extractArchiveSource(source)
+		if err != nil {
+			return "", "", noop, err
+		}
+		return dir, skill.ExtractSkillName(source), func() { os.RemoveAll(dir) }, nil
+	}
+
+	fetcher := git.NewFetcher()
+	if git.IsTreeURL(source) {
+		treeURL, err := git.ParseTreeURL(source)
+		if err != nil {
+			return "", "", noop, err
+		}
+		color.Cyan("📦 Cloning repository (branch: %s)...\n", treeURL.Branch)
+		dir, err := fetcher.CloneToTempWithBranch(treeURL.CloneURL(), treeURL.Branch)
+		if err != nil {
+			return "", "", noop, err
+		}
+		return filepath.Join(dir, treeURL.Path), filepath.Base(treeURL.Path), func() { os.RemoveAll(dir) }, nil
+	}
+
+	color.Cyan("📦 Cloning repository...\n")
+	dir, err := fetcher.CloneToTemp(source)
+	if err != nil {
+		return "", "", noop, err
+	}
+	return dir, skill.ExtractSkillName(source), func() { os.RemoveAll(dir) }, nil
+}
+
+// sourceSkills 扫描来源目录中的 skill，目录本身是 skill 时返回单个 skill
+func sourceSkills(dir, rootName string) ([]skill.SkillInfo, error) {
+	if skill.ValidateSkillDir(dir) == nil {
+		return []skill.SkillInfo{skill.LoadSkillInfo(dir, rootName)}, nil
+	}
+	skills, err := skill.ScanSkills(dir)
+	if err != nil {
+		return nil, fmt.Errorf("scan failed: %w", err)
+	}
+	if len(skills) == 0 {
+		return nil, fmt.Errorf("no skills found in %s", dir)
+	}
+	return skills, nil
+}
+
+// gitUserName 读取 git user.name 作为默认维护者
+func gitUserName() string {
+	out, err := exec.Command("git", "config", "user.name").Output()
+	if name := strings.TrimSpace(string(out)); err == nil && name != "" {
+		return name
+	}
+	return "skillsync"
+}
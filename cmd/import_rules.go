@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/AlfonsSkills/SkillSync/internal/rules"
+	"github.com/AlfonsSkills/SkillSync/internal/skill"
+	"github.com/AlfonsSkills/SkillSync/internal/target"
+)
+
+var (
+	importOutput    string
+	importLocal     bool
+	importNoInstall bool
+)
+
+// importRulesCmd import-rules command
+var importRulesCmd = &cobra.Command{
+	Use:   "import-rules [dir]",
+	Short: "Convert existing rule files into skills and install them",
+	Long: `Convert existing agent rule files into spec-compliant skills.
+
+Discovered files (relative to the project root or [dir]):
+  .cursorrules
+  .cursor/rules/*.mdc
+  .clinerules (file or directory)
+  .roo/rules/*, .roorules
+  .github/copilot-instructions.md
+  .github/instructions/*.instructions.md
+
+Skill names come from the file name, or from the first heading for single-file
+rules such as .cursorrules. Descriptions come from existing frontmatter, else the
+first heading and paragraph. Cursor globs and Copilot applyTo patterns are kept
+under metadata.
+
+Generated skills are installed like any other skill. Without --target they go to
+every supported tool.
+
+Examples:
+  skillsync import-rules
+  skillsync import-rules --local
+  skillsync import-rules -o ./skills --no-install
+  skillsync import-rules ../legacy-project -t claude,codex`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runImportRules,
+}
+
+func init() {
+	rootCmd.AddCommand(importRulesCmd)
+	importRulesCmd.Flags().StringVarP(&importOutput, "output", "o", "", "Keep generated skills in this directory (default: temporary)")
+	importRulesCmd.Flags().BoolVarP(&importLocal, "local", "l", false, "Install to project-local skills directories only")
+	importRulesCmd.Flags().BoolVar(&importNoInstall, "no-install", false, "Only generate skills (requires --output)")
+}
+
+func runImportRules(cmd *cobra.Command, args []string) error {
+	if importNoInstall && importOutput == "" {
+		return fmt.Errorf("--no-install requires --output")
+	}
+
+	root := ""
+	if len(args) > 0 {
+		root = args[0]
+	} else if projectRoot, err := findProjectRoot(); err == nil {
+		root = projectRoot
+	} else {
+		root = "."
+	}
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+
+	// Step 1: 发现规则文件
+	found, err := rules.Discover(root)
+	if err != nil {
+		color.Red("❌ %v\n", err)
+		return err
+	}
+	if len(found) == 0 {
+		color.Yellow("⚠ No rule files found in %s\n", root)
+		return nil
+	}
+
+	// Step 2: 选择要导入的规则（默认全选）
+	color.Cyan("🔍 Found %d rule file(s)\n\n", len(found))
+	var options []string
+	var defaults []int
+	cyan := color.New(color.FgCyan).SprintFunc()
+	for i, r := range found {
+		options = append(options, fmt.Sprintf("%s ← %s", cyan(r.Name), r.RelPath))
+		defaults = append(defaults, i)
+	}
+	var selectedIndices []int
+	prompt := &survey.MultiSelect{
+		Message:  "Select rules to import:",
+		Options:  options,
+		Default:  defaults,
+		PageSize: 10,
+	}
+	if err := survey.AskOne(prompt, &selectedIndices); err != nil {
+		return fmt.Errorf("selection cancelled: %w", err)
+	}
+	if len(selectedIndices) == 0 {
+		color.Yellow("⚠ No rules selected\n")
+		return nil
+	}
+	fmt.Println()
+
+	// Step 3: 生成 skill 目录
+	outDir := importOutput
+	if outDir == "" {
+		if outDir, err = os.MkdirTemp("", "skillsync-rules-*"); err != nil {
+			return err
+		}
+		defer os.RemoveAll(outDir)
+	} else if outDir, err = filepath.Abs(outDir); err != nil {
+		return err
+	}
+
+	var skills []skill.SkillInfo
+	for _, idx := range selectedIndices {
+		r := found[idx]
+		dir, err := rules.WriteSkill(r, outDir)
+		if err != nil {
+			color.Red("❌ Convert %s failed: %v\n", r.RelPath, err)
+			return err
+		}
+		skills = append(skills, skill.LoadSkillInfo(dir, r.Name))
+		if importOutput != "" {
+			color.Green("   ✓ %s → %s\n", r.RelPath, dir)
+		}
+	}
+	if importNoInstall {
+		color.Green("\n✅ Generated %d skill(s) in %s\n", len(skills), outDir)
+		return nil
+	}
+
+	// Step 4: 解析目标工具与安装范围，未指定 --target 时安装到全部工具
+	targets := targetFlags
+	if len(targets) == 0 {
+		for _, p := range target.AllProviders() {
+			targets = append(targets, p.Type().String())
+		}
+	}
+	providers, _, err := resolveTargetProviders(targets, skills)
+	if err != nil {
+		return err
+	}
+	installGlobal, installLocal, projectRoot, err := resolveLocalInstall(importLocal)
+	if err != nil {
+		return err
+	}
+	providers = applyInstallStrategy(providers, strategyCopy, installGlobal, installLocal, projectRoot)
+
+	copyOpts, err := resolveCopyOptions(skills, nil, nil, string(skill.SymlinkDereference))
+	if err != nil {
+		return err
+	}
+	showInstallPreview(skills, nil, copyOpts, providers, categoryOptions{}, false, installGlobal, installLocal, projectRoot)
+
+	// Step 5: 确认并安装
+	var confirmInstall bool
+	confirmPrompt := &survey.Confirm{
+		Message: "Proceed with installation?",
+		Default: true,
+	}
+	if err := survey.AskOne(confirmPrompt, &confirmInstall); err != nil {
+		return fmt.Errorf("cancelled: %w", err)
+	}
+	if !confirmInstall {
+		color.Yellow("Installation cancelled\n")
+		return nil
+	}
+
+	totalInstalled := installSkillsToProviders(skills, installPlan{
+		providers:     providers,
+		copyOpts:      copyOpts,
+		installGlobal: installGlobal,
+		installLocal:  installLocal,
+		projectRoot:   projectRoot,
+	})
+	if totalInstalled == 0 {
+		color.Red("\n❌ No skills installed successfully\n")
+		return fmt.Errorf("installation failed")
+	}
+
+	color.Green("\n✅ Import complete! %d skill(s) installed\n", totalInstalled)
+	return nil
+}
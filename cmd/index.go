@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/AlfonsSkills/SkillSync/internal/export"
+	"github.com/AlfonsSkills/SkillSync/internal/target"
+)
+
+var (
+	indexFiles  []string
+	indexDryRun bool
+)
+
+// indexFileTargets 支持写入的指令文件（key: --file 取值）
+var indexFileTargets = map[string]string{
+	"agents":  "AGENTS.md",
+	"copilot": filepath.Join(".github", "copilot-instructions.md"),
+	"claude":  "CLAUDE.md",
+}
+
+// indexFileOrder 指令文件的固定顺序
+var indexFileOrder = []string{"agents", "copilot", "claude"}
+
+// indexCmd index command
+var indexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Write a project skill index into AGENTS.md and other instruction files",
+	Long: `Write a managed section listing project skills into agent instruction files.
+
+Tools without native skill support still read AGENTS.md, .github/copilot-instructions.md
+or CLAUDE.md. The section lists each project skill's name, description and SKILL.md
+path. It is delimited by <!-- skillsync:begin --> / <!-- skillsync:end --> markers.
+Re-running updates it in place and leaves the rest of the file untouched.
+
+Files (--file): agents (AGENTS.md), copilot (.github/copilot-instructions.md), claude (CLAUDE.md), all
+
+Examples:
+  skillsync index
+  skillsync index --file all
+  skillsync index --file agents,claude --target claude,codex
+  skillsync index --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runIndex,
+}
+
+func init() {
+	rootCmd.AddCommand(indexCmd)
+	indexCmd.Flags().StringSliceVarP(&indexFiles, "file", "f", []string{"agents"}, "Instruction files to update (agents, copilot, claude, all)")
+	indexCmd.Flags().BoolVar(&indexDryRun, "dry-run", false, "Print the generated section without writing files")
+}
+
+func runIndex(cmd *cobra.Command, args []string) error {
+	files, err := parseIndexFiles(indexFiles)
+	if err != nil {
+		return err
+	}
+
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		color.Red("❌ Not in a git repository\n")
+		return fmt.Errorf("index requires a project context: %w", err)
+	}
+
+	providers, err := target.ParseProviders(targetFlags)
+	if err != nil {
+		return err
+	}
+
+	// 关键步骤：同名 skill 合并为一条，列出各工具目录下的 SKILL.md
+	var entries []export.IndexEntry
+	byName := make(map[string]int)
+	for _, s := range scanProjectSkillsWithProviders(providers) {
+		if !s.Valid {
+			continue
+		}
+		rel, err := filepath.Rel(projectRoot, filepath.Join(s.Path, "SKILL.md"))
+		if err != nil {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+		if idx, ok := byName[s.Name]; ok {
+			if !slices.Contains(entries[idx].Paths, rel) {
+				entries[idx].Paths = append(entries[idx].Paths, rel)
+			}
+			continue
+		}
+		byName[s.Name] = len(entries)
+		entries = append(entries, export.IndexEntry{Name: s.Name, Description: s.Description, Paths: []string{rel}})
+	}
+
+	section := export.RenderSkillIndex(entries)
+	if indexDryRun {
+		fmt.Print(section)
+		return nil
+	}
+
+	color.Cyan("📇 Indexing %d project skill(s)\n", len(entries))
+	for _, key := range files {
+		file := filepath.Join(projectRoot, indexFileTargets[key])
+		changed, err := export.UpdateManagedSection(file, section)
+		if err != nil {
+			color.Red("   ❌ %v\n", err)
+			return err
+		}
+		if changed {
+			color.Green("   ✓ Updated %s\n", file)
+		} else {
+			color.White("   • Up to date: %s\n", file)
+		}
+	}
+	return nil
+}
+
+// parseIndexFiles 解析 --file 取值，返回按固定顺序排列的 key
+func parseIndexFiles(values []string) ([]string, error) {
+	selected := make(map[string]bool)
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "all" {
+			for _, key := range indexFileOrder {
+				selected[key] = true
+			}
+			continue
+		}
+		if _, ok := indexFileTargets[v]; !ok {
+			return nil, fmt.Errorf("unknown file: %s (supported: agents, copilot, claude, all)", v)
+		}
+		selected[v] = true
+	}
+
+	var keys []string
+	for _, key := range indexFileOrder {
+		if selected[key] {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
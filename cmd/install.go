@@ -10,11 +10,29 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/AlfonsSkills/SkillSync/internal/git"
+	"github.com/AlfonsSkills/SkillSync/internal/pack"
+	"github.com/AlfonsSkills/SkillSync/internal/scan"
 	"github.com/AlfonsSkills/SkillSync/internal/skill"
+	"github.com/AlfonsSkills/SkillSync/internal/target"
 )
 
 var (
 	localInstall bool
+	noDeps       bool
+	failOn       string
+	excludeGlobs []string
+	includeGlobs []string
+	symlinkMode  string
+	installFmt   string
+	installGroup []string
+	strategy     string
+	installCat   categoryOptions
+)
+
+// 安装输出格式
+const (
+	formatSkill       = "skill"        // 拷贝 skill 目录（默认）
+	formatCursorRules = "cursor-rules" // 支持规则的工具（Cursor）导出为项目级 .mdc 规则
 )
 
 // installCmd install command
@@ -25,6 +43,7 @@ var installCmd = &cobra.Command{
 
 Repository formats:
   user/repo                                   Use GitHub (default)
+  ./my-skill.tar.gz                           Local archive created by 'skillsync pack'
   https://github.com/user/repo                Full URL
   https://github.com/user/repo/tree/br/path   Specific skill path (with default selection)
 
@@ -32,6 +51,12 @@ Examples:
   skillsync install AlfonsSkills/skills
   skillsync install AlfonsSkills/skills --target gemini
   skillsync install AlfonsSkills/skills --local
+  skillsync install AlfonsSkills/skills --group essentials -t claude
+  skillsync install AlfonsSkills/skills --fail-on high
+  skillsync install AlfonsSkills/skills --format cursor-rules
+  skillsync install AlfonsSkills/skills --strategy shared -t goose,claude
+  skillsync install AlfonsSkills/skills -t codex --category public
+  skillsync install AlfonsSkills/skills --exclude "tests/" --exclude "*.psd"
   skillsync install https://github.com/AlfonsSkills/skills.git -t claude,codex
   skillsync install https://github.com/AlfonsSkills/skills/tree/main/all-money-back-my-home`,
 	Args: cobra.ExactArgs(1),
@@ -41,21 +66,60 @@ Examples:
 func init() {
 	rootCmd.AddCommand(installCmd)
 	installCmd.Flags().BoolVarP(&localInstall, "local", "l", false, "Install to project-local skills directories only")
+	installCmd.Flags().BoolVar(&noDeps, "no-deps", false, "Skip installing dependencies declared in SKILL.md")
+	installCmd.Flags().StringSliceVar(&excludeGlobs, "exclude", nil, "Exclude files matching gitignore-style patterns (repeatable)")
+	installCmd.Flags().StringSliceVar(&includeGlobs, "include", nil, "Re-include files excluded by .skillsyncignore or --exclude (repeatable)")
+	installCmd.Flags().StringVar(&symlinkMode, "symlinks", string(skill.SymlinkDereference), "Symlink handling: dereference, preserve or reject (links outside the skill are always refused)")
+	installCmd.Flags().StringVar(&installFmt, "format", formatSkill, "Output format: skill, or cursor-rules to write Cursor .mdc project rules")
+	installCmd.Flags().StringSliceVarP(&installGroup, "group", "g", nil, "Install every skill in these groups or plugins without prompting (from skillsync.yaml or marketplace.json)")
+	installCmd.Flags().StringVar(&strategy, "strategy", strategyCopy, "Install strategy: copy, or shared to install once into ~/.config/agents/skills for tools that read it")
+	installCmd.Flags().StringVar(&installCat.category, "category", "", "Global category subdirectory for tools that have them (e.g. Codex public); ignored by other tools")
+	installCmd.Flags().BoolVar(&installCat.allowProtected, "allow-protected", false, "Allow overwriting skills in read-only categories such as Codex .system")
+	installCmd.Flags().StringVar(&failOn, "fail-on", "", "Abort when the security scan finds issues at or above this severity (low, medium, high)")
 }
 
 func runInstall(cmd *cobra.Command, args []string) error {
 	source := args[0]
 
+	if installFmt != formatSkill && installFmt != formatCursorRules {
+		return fmt.Errorf("unknown format: %s (supported: %s, %s)", installFmt, formatSkill, formatCursorRules)
+	}
+	rulesFormat := installFmt == formatCursorRules
+	if strategy != strategyCopy && strategy != strategyShared {
+		return fmt.Errorf("unknown strategy: %s (supported: %s, %s)", strategy, strategyCopy, strategyShared)
+	}
+
+	failThreshold := scan.SeverityNone
+	if failOn != "" {
+		var err error
+		if failThreshold, err = scan.ParseSeverity(failOn); err != nil {
+			return err
+		}
+	}
+
 	// Create Git fetcher
 	fetcher := git.NewFetcher()
 
 	var tempDir string
-	var targetPath string // 指定的子目录路径
+	var repoSource = source   // 用于依赖解析的仓库输入
+	var repoRef string        // 仓库分支（Tree URL 时有效）
+	var targetPath string     // 指定的子目录路径
 	var targetFullPath string // 解析后的目标目录完整路径
-	var err error
+
+	verifier, err := newSourceVerifier(fetcher, verifyPolicyFlag)
+	if err != nil {
+		color.Red("❌ %v\n", err)
+		return err
+	}
 
 	// 检测是否为 Tree URL (支持 GitHub, 未来可扩展 GitLab 等)
-	if git.IsTreeURL(source) {
+	if isArchiveSource(source) {
+		// 本地归档（skillsync pack 生成），先解压再校验分离签名
+		repoSource, _ = filepath.Abs(source)
+		color.Cyan("📦 Extracting archive...\n")
+		color.White("   Source: %s\n\n", repoSource)
+		tempDir, err = extractArchiveSource(repoSource)
+	} else if git.IsTreeURL(source) {
 		treeURL, parseErr := git.ParseTreeURL(source)
 		if parseErr != nil {
 			color.Red("❌ Invalid tree URL: %v\n", parseErr)
@@ -68,6 +132,8 @@ func runInstall(cmd *cobra.Command, args []string) error {
 
 		tempDir, err = fetcher.CloneToTempWithBranch(treeURL.CloneURL(), treeURL.Branch)
 		targetPath = treeURL.Path
+		repoSource = treeURL.CloneURL()
+		repoRef = treeURL.Branch
 	} else {
 		// 原有逻辑
 		color.Cyan("📦 Cloning repository...\n")
@@ -81,6 +147,16 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	}
 	defer os.RemoveAll(tempDir)
 
+	// 关键步骤：安装前按策略校验来源签名
+	if isArchiveSource(source) {
+		err = verifier.checkArchive(repoSource, tempDir)
+	} else {
+		err = verifier.checkGit(repoSource, repoRef, tempDir)
+	}
+	if err != nil {
+		return err
+	}
+
 	// 如果指定了 targetPath，验证路径是否存在
 	if targetPath != "" {
 		// 关键步骤：根据 Tree URL 计算 skill 目标目录
@@ -101,21 +177,18 @@ func runInstall(cmd *cobra.Command, args []string) error {
 
 	// Step 1: Build skill list (Tree URL 指定时仅选择该 skill)
 	var skills []skill.SkillInfo
+	var groups []skill.SkillGroup
 	if targetPath != "" {
-		// 关键步骤：tree URL 已明确 skill，补充读取描述并复用展示格式
-		desc := skill.ReadSkillDescription(targetFullPath)
-		skills = []skill.SkillInfo{{
-			Name: filepath.Base(targetFullPath),
-			Path: targetFullPath,
-			Desc: desc,
-		}}
+		// 关键步骤：tree URL 已明确 skill，读取 frontmatter 解析名称与描述
+		skills = []skill.SkillInfo{skill.LoadSkillInfo(targetFullPath, filepath.Base(targetFullPath))}
 	} else {
-		// Scan skills in repository
-		skills, err = skill.ScanSkills(tempDir)
+		// Scan skills in repository（存在 skillsync.yaml 或插件市场清单时按清单分组）
+		groups, err = skill.ScanSkillGroups(tempDir)
 		if err != nil {
 			color.Red("❌ Scan failed: %v\n", err)
 			return err
 		}
+		skills = skill.FlattenGroups(groups)
 
 		// Handle single-skill repo (root is the skill)
 		if len(skills) == 0 {
@@ -124,18 +197,26 @@ func runInstall(cmd *cobra.Command, args []string) error {
 				return fmt.Errorf("no skills found in repository")
 			}
 			repoName := skill.ExtractSkillName(source)
-			skills = []skill.SkillInfo{{
-				Name: repoName,
-				Path: tempDir,
-			}}
+			skills = []skill.SkillInfo{skill.LoadSkillInfo(tempDir, repoName)}
 		}
 	}
 
+	// 提示 frontmatter name 与目录名不一致的 skill
+	warnNameMismatch(skills)
+
 	// Step 2: Select skills to install
-	color.Green("✓ Found %d skill(s)\n\n", len(skills))
+	if hasNamedGroups(groups) {
+		color.Green("✓ Found %d skill(s) in %d group(s)\n\n", len(skills), countNamedGroups(groups))
+	} else {
+		color.Green("✓ Found %d skill(s)\n\n", len(skills))
+	}
 
 	var selectedSkills []skill.SkillInfo
-	if targetPath != "" {
+	switch {
+	case targetPath != "":
+		if len(installGroup) > 0 {
+			return fmt.Errorf("--group cannot be used with a tree URL")
+		}
 		// Tree URL 已明确 skill 路径，直接使用该 skill，并复用名称/描述展示格式
 		cyan := color.New(color.FgCyan).SprintFunc()
 		s := skills[0]
@@ -145,52 +226,103 @@ func runInstall(cmd *cobra.Command, args []string) error {
 			color.White("   %s\n\n", cyan(s.Name))
 		}
 		selectedSkills = skills
-	} else {
-		var options []string
+	case len(installGroup) > 0:
+		// 关键步骤：--group 按分组选择，无需交互
+		selectedSkills, err = skillsInGroups(groups, installGroup)
+		if err != nil {
+			color.Red("❌ %v\n", err)
+			return err
+		}
 		cyan := color.New(color.FgCyan).SprintFunc()
-		for _, s := range skills {
-			if s.Desc != "" {
-				options = append(options, fmt.Sprintf("%s - %s", cyan(s.Name), s.Desc))
-			} else {
-				options = append(options, cyan(s.Name))
-			}
+		for _, s := range selectedSkills {
+			color.White("   • %s\n", cyan(s.Name))
 		}
-
-		var selectedIndices []int
-		skillPrompt := &survey.MultiSelect{
-			Message:  "Select skills to install:",
-			Options:  options,
-			PageSize: 10,
+		fmt.Println()
+	default:
+		if len(groups) == 0 {
+			groups = []skill.SkillGroup{{Skills: skills}}
 		}
-		if err := survey.AskOne(skillPrompt, &selectedIndices); err != nil {
-			return fmt.Errorf("selection cancelled: %w", err)
+		selectedSkills, err = selectSkills(groups)
+		if err != nil {
+			return err
 		}
-
-		if len(selectedIndices) == 0 {
+		if len(selectedSkills) == 0 {
 			color.Yellow("⚠ No skills selected\n")
 			return nil
 		}
+		fmt.Println()
+	}
 
-		for _, idx := range selectedIndices {
-			selectedSkills = append(selectedSkills, skills[idx])
+	// Step 2.5: Resolve dependencies declared in SKILL.md
+	var deps []skill.ResolvedDependency
+	if !noDeps {
+		loader := newGitSourceLoader(fetcher, verifier, repoSource, repoRef, tempDir)
+		defer loader.Cleanup()
+
+		roots := make([]skill.RootSkill, 0, len(selectedSkills))
+		for _, s := range selectedSkills {
+			roots = append(roots, skill.RootSkill{Info: s, Source: repoSource, Ref: repoRef})
+		}
+		deps, err = skill.ResolveDependencies(roots, loader)
+		if err != nil {
+			color.Red("❌ Dependency resolution failed: %v\n", err)
+			return err
+		}
+	}
+
+	// 关键步骤：依赖优先安装
+	installSkills := make([]skill.SkillInfo, 0, len(deps)+len(selectedSkills))
+	for _, d := range deps {
+		installSkills = append(installSkills, d.Info)
+	}
+	installSkills = append(installSkills, selectedSkills...)
+
+	// 每个 skill 合并 .skillsyncignore 与 --exclude/--include 规则
+	copyOpts, err := resolveCopyOptions(installSkills, excludeGlobs, includeGlobs, symlinkMode)
+	if err != nil {
+		color.Red("❌ %v\n", err)
+		return err
+	}
+
+	// 关键步骤：扫描实际会被安装的文件，--fail-on 在交互前终止
+	reports, err := scanInstallSkills(installSkills, copyOpts)
+	if err != nil {
+		color.Red("❌ Security scan failed: %v\n", err)
+		return err
+	}
+	if failThreshold != scan.SeverityNone {
+		if max := maxSeverity(reports); max >= failThreshold {
+			showSecurityReport(reports)
+			color.Red("❌ Security scan found %s severity issues (--fail-on %s)\n", max, failThreshold)
+			return fmt.Errorf("security scan failed: %s severity issues found", max)
 		}
-		fmt.Println()
 	}
 
 	// Step 3: Resolve target providers (interactive if not specified)
-	providers, _, err := resolveTargetProviders(targetFlags)
+	// cursor-rules 格式未指定目标时默认仅导出到 Cursor
+	targets := targetFlags
+	if rulesFormat && len(targets) == 0 {
+		targets = []string{target.ToolCursor.String()}
+	}
+	providers, _, err := resolveTargetProviders(targets, installSkills)
 	if err != nil {
 		return err
 	}
 
 	// Step 4: Resolve install scope (global/local)
-	installGlobal, installLocal, projectRoot, err := resolveLocalInstall(localInstall)
+	// 规则文件仅存在于项目级，cursor-rules 格式强制安装到项目目录
+	installGlobal, installLocal, projectRoot, err := resolveLocalInstall(localInstall || rulesFormat)
 	if err != nil {
 		return err
 	}
+	providers = applyInstallStrategy(providers, strategy, installGlobal, installLocal, projectRoot)
+	if err := checkCategoryFlag(providers, installCat.category); err != nil {
+		return err
+	}
 
 	// Step 5: Show installation preview
-	showInstallPreview(selectedSkills, providers, installGlobal, installLocal, projectRoot)
+	showInstallPreview(installSkills, deps, copyOpts, providers, installCat, rulesFormat, installGlobal, installLocal, projectRoot)
+	showSecurityReport(reports)
 
 	// Step 6: Confirm and execute installation
 	var confirmInstall bool
@@ -207,57 +339,16 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	}
 
 	// Execute installation
-	copyOpts := skill.DefaultCopyOptions()
-	totalInstalled := 0
-
-	for _, s := range selectedSkills {
-		color.Cyan("\n📦 Installing: %s\n", s.Name)
-		installedCount := 0
-
-		for _, p := range providers {
-			// Install to global directory
-			if installGlobal {
-				globalDir, err := p.EnsureInstallDir()
-				if err != nil {
-					color.Yellow("   ⚠ Skipping %s (global): %v\n", p.DisplayName(), err)
-				} else {
-					destDir := filepath.Join(globalDir, s.Name)
-					if _, err := os.Stat(destDir); !os.IsNotExist(err) {
-						os.RemoveAll(destDir)
-					}
-					if err := skill.CopyDir(s.Path, destDir, copyOpts); err != nil {
-						color.Yellow("   ⚠ Copy to %s failed: %v\n", p.DisplayName(), err)
-					} else {
-						color.Green("   ✓ %s: %s\n", p.DisplayName(), destDir)
-						installedCount++
-					}
-				}
-			}
-
-			// Install to project directory
-			if installLocal && projectRoot != "" {
-				localDir, err := p.EnsureLocalInstallDir(projectRoot)
-				if err != nil {
-					color.Yellow("   ⚠ Skipping %s (project): %v\n", p.DisplayName(), err)
-				} else {
-					destDir := filepath.Join(localDir, s.Name)
-					if _, err := os.Stat(destDir); !os.IsNotExist(err) {
-						os.RemoveAll(destDir)
-					}
-					if err := skill.CopyDir(s.Path, destDir, copyOpts); err != nil {
-						color.Yellow("   ⚠ Copy to .%s/skills failed: %v\n", p.Type(), err)
-					} else {
-						color.Green("   ✓ .%s/skills: %s\n", p.Type(), destDir)
-						installedCount++
-					}
-				}
-			}
-		}
-
-		if installedCount > 0 {
-			totalInstalled++
-		}
-	}
+	totalInstalled := installSkillsToProviders(installSkills, installPlan{
+		providers:     providers,
+		copyOpts:      copyOpts,
+		installGlobal: installGlobal,
+		installLocal:  installLocal,
+		projectRoot:   projectRoot,
+		rulesFormat:   rulesFormat,
+		category:      installCat,
+		provenance:    verifier.provenanceFor,
+	})
 
 	if totalInstalled == 0 {
 		color.Red("\n❌ No skills installed successfully\n")
@@ -271,3 +362,49 @@ func runInstall(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// resolveCopyOptions 为每个 skill 构建拷贝选项（key: skill 路径）
+func resolveCopyOptions(skills []skill.SkillInfo, excludes, includes []string, symlinks string) (map[string]skill.CopyOptions, error) {
+	base, err := skill.DefaultCopyOptions().WithPatterns(excludes, includes)
+	if err != nil {
+		return nil, err
+	}
+	if base.Symlinks, err = skill.ParseSymlinkPolicy(symlinks); err != nil {
+		return nil, err
+	}
+	opts := make(map[string]skill.CopyOptions, len(skills))
+	for _, s := range skills {
+		o, err := base.ForSkill(s.Path)
+		if err != nil {
+			return nil, err
+		}
+		opts[s.Path] = o
+	}
+	return opts, nil
+}
+
+// isArchiveSource 判断安装来源是否为本地 skill 归档
+func isArchiveSource(source string) bool {
+	if !pack.IsArchive(source) {
+		return false
+	}
+	info, err := os.Stat(source)
+	return err == nil && !info.IsDir()
+}
+
+// writeProvenance 写入来源记录，失败时仅提示不影响安装
+func writeProvenance(destDir string, p skill.Provenance) {
+	if err := skill.WriteProvenance(destDir, p); err != nil {
+		color.Yellow("   ⚠ %v\n", err)
+	}
+}
+
+// warnNameMismatch 提示 frontmatter name 与目录名不一致的 skill
+// 安装时以 frontmatter name 为准
+func warnNameMismatch(skills []skill.SkillInfo) {
+	for _, s := range skills {
+		if s.NameMismatch() {
+			color.Yellow("⚠ Skill name '%s' (SKILL.md) differs from directory '%s', installing as '%s'\n", s.Name, s.DirName, s.Name)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/AlfonsSkills/SkillSync/internal/export"
+	"github.com/AlfonsSkills/SkillSync/internal/skill"
+	"github.com/AlfonsSkills/SkillSync/internal/target"
+)
+
+// 安装策略
+const (
+	strategyCopy   = "copy"   // 拷贝到每个目标工具的目录（默认）
+	strategyShared = "shared" // 读取 agents 通用目录的工具只安装到共享目录
+)
+
+// applyInstallStrategy 按安装策略调整目标工具
+// shared 策略在有工具读取通用目录时自动加入 agents 目标
+// 目标包含 agents 时，读取通用目录的工具并入 agents，不再单独拷贝，避免同一 skill 被加载两次
+func applyInstallStrategy(providers []target.ToolProvider, strategy string, installGlobal, installLocal bool, projectRoot string) []target.ToolProvider {
+	localRoot := ""
+	if installLocal {
+		localRoot = projectRoot
+	}
+	isAgents := func(p target.ToolProvider) bool { return p.Type() == target.ToolAgents }
+	reads := func(p target.ToolProvider) bool { return target.ReadsSharedDir(p, installGlobal, localRoot) }
+
+	if strategy == strategyShared && !slices.ContainsFunc(providers, isAgents) && slices.ContainsFunc(providers, reads) {
+		if agents, err := target.GetProvider(target.ToolAgents); err == nil {
+			providers = append(providers, agents)
+		}
+	}
+
+	providers = target.CollapseShared(providers, installGlobal, localRoot)
+	for _, p := range providers {
+		if members := target.Members(p); isAgents(p) && len(members) > 1 {
+			var names []string
+			for _, m := range members[1:] {
+				names = append(names, m.DisplayName())
+			}
+			dir, _ := p.GlobalSkillsDir()
+			color.Cyan("🔗 Shared directory: %s\n", dir)
+			color.HiCyan("   Also loaded by: %s\n\n", strings.Join(names, ", "))
+		}
+	}
+	return providers
+}
+
+// skillTarget skill 的一个安装目标，skip 非空时跳过并说明原因
+type skillTarget struct {
+	provider target.ToolProvider
+	skip     string
+}
+
+// skillTargets 按兼容性声明确定 skill 的安装目标，规则与 allowsProvider 相同
+// 并入 agents 的工具都会加载共享目录，其中有工具声明不兼容时跳过共享目录，
+// 其余读取共享目录的工具改为拷贝到各自的目录，不兼容的工具各自跳过
+// projectRoot 仅在安装到项目目录时传入，用于判断哪些工具与共享目录相同
+func skillTargets(providers []target.ToolProvider, compat skill.Compatibility, projectRoot string) []skillTarget {
+	var result []skillTarget
+	for _, p := range providers {
+		ok, reason := allowsProvider(compat, p)
+		members := target.Members(p)
+		if ok || p.Type() != target.ToolAgents || len(members) == 1 {
+			result = append(result, skillTarget{provider: p, skip: reason})
+			continue
+		}
+
+		// 关键步骤：重新按目录分组，第一组为共享目录本身（含直接安装到共享目录的工具）
+		groups := target.GroupByDir(members, projectRoot)
+		result = append(result, skillTarget{provider: groups[0], skip: reason})
+		for _, g := range groups[1:] {
+			_, reason := allowsProvider(compat, g)
+			result = append(result, skillTarget{provider: g, skip: reason})
+		}
+	}
+	return result
+}
+
+// installPlan 描述一次安装的目标与范围
+type installPlan struct {
+	providers     []target.ToolProvider
+	copyOpts      map[string]skill.CopyOptions // key: skill 路径
+	installGlobal bool
+	installLocal  bool
+	projectRoot   string
+	rulesFormat   bool                                   // 支持规则的工具导出为规则文件
+	category      categoryOptions                        // 有分类工具的全局安装位置
+	provenance    func(skill.SkillInfo) skill.Provenance // 为空时不写入来源记录
+}
+
+// installSkillsToProviders 将 skill 安装到各目标工具
+// 返回: 至少安装成功一处的 skill 数量
+func installSkillsToProviders(skills []skill.SkillInfo, plan installPlan) int {
+	totalInstalled := 0
+
+	for _, s := range skills {
+		color.Cyan("\n📦 Installing: %s\n", s.Name)
+		installedCount := 0
+		var provenance skill.Provenance
+		if plan.provenance != nil {
+			provenance = plan.provenance(s)
+		}
+
+		localRoot := ""
+		if plan.installLocal {
+			localRoot = plan.projectRoot
+		}
+		for _, st := range skillTargets(plan.providers, s.Compat, localRoot) {
+			p := st.provider
+			// 跳过声明不兼容的工具
+			if st.skip != "" {
+				color.Yellow("   ⏭ Skipping %s: %s\n", p.DisplayName(), st.skip)
+				continue
+			}
+
+			// Install to global directory
+			if plan.installGlobal {
+				destDir, err := placeSkill(p, s, plan.copyOpts[s.Path], plan.category, "")
+				if err != nil {
+					color.Yellow("   ⚠ Skipping %s (global): %v\n", p.DisplayName(), err)
+				} else {
+					if plan.provenance != nil && destDir != "" {
+						writeProvenance(destDir, provenance)
+					}
+					color.Green("   ✓ %s: %s\n", p.DisplayName(), destDir)
+					installedCount++
+				}
+			}
+
+			// 导出为项目级规则文件
+			if rp, ok := p.(target.RulesProvider); ok && plan.rulesFormat && plan.installLocal && plan.projectRoot != "" {
+				rulePath, err := export.ExportCursorRule(s, rp.LocalRulesDir(plan.projectRoot), plan.copyOpts[s.Path])
+				if err != nil {
+					color.Yellow("   ⚠ Export to %s rules failed: %v\n", p.DisplayName(), err)
+				} else {
+					color.Green("   ✓ %s rules: %s\n", p.DisplayName(), rulePath)
+					installedCount++
+				}
+				continue
+			}
+
+			// Install to project directory
+			if plan.installLocal && plan.projectRoot != "" {
+				destDir, err := placeSkill(p, s, plan.copyOpts[s.Path], plan.category, plan.projectRoot)
+				if err != nil {
+					color.Yellow("   ⚠ Skipping %s (project): %v\n", p.DisplayName(), err)
+				} else {
+					if plan.provenance != nil && destDir != "" {
+						writeProvenance(destDir, provenance)
+					}
+					color.Green("   ✓ .%s/skills: %s\n", p.Type(), destDir)
+					installedCount++
+				}
+			}
+		}
+
+		if installedCount > 0 {
+			totalInstalled++
+		}
+	}
+
+	return totalInstalled
+}
+
+// ensureSkillParentDir 确保并返回 skill 在工具中的父目录
+// 全局安装到有分类的工具时按 category 选择分类子目录，projectRoot 非空时为项目目录
+func ensureSkillParentDir(p target.ToolProvider, skillName string, category categoryOptions, projectRoot string) (string, error) {
+	if projectRoot != "" {
+		return p.EnsureLocalInstallDir(projectRoot)
+	}
+	dir, err := categoryInstallDir(p, skillName, category)
+	if err != nil {
+		return "", err
+	}
+	if dir == "" {
+		return p.EnsureInstallDir()
+	}
+	return dir, os.MkdirAll(dir, 0755)
+}
+
+// placeSkill 将 skill 安装到工具的全局目录（projectRoot 为空）或项目目录
+// 全局安装到有分类的工具时按 category 选择分类子目录
+// 自行管理安装的工具（外部插件）接收按排除规则拷贝后的临时目录，不支持时回退为目录拷贝
+// 返回: 安装后的 skill 目录（插件未返回时为空）
+func placeSkill(p target.ToolProvider, s skill.SkillInfo, opts skill.CopyOptions, category categoryOptions, projectRoot string) (string, error) {
+	if sm, ok := p.(target.SkillManager); ok {
+		stage, err := os.MkdirTemp("", "skillsync-stage-*")
+		if err != nil {
+			return "", err
+		}
+		defer os.RemoveAll(stage)
+		src := filepath.Join(stage, s.Name)
+		if err := skill.CopyDir(s.Path, src, opts); err != nil {
+			return "", err
+		}
+		destDir, err := sm.InstallSkill(src, s.Name, projectRoot)
+		if !errors.Is(err, target.ErrUnsupported) {
+			return destDir, err
+		}
+	}
+
+	dir, err := ensureSkillParentDir(p, s.Name, category, projectRoot)
+	if err != nil {
+		return "", err
+	}
+
+	// 关键步骤：删除同名 skill 的所有已有副本（含改名前目录名下的副本），避免重复加载
+	for _, existing := range skill.FindSkillDirs(dir, s.Name) {
+		if err := os.RemoveAll(existing); err != nil {
+			return "", fmt.Errorf("remove existing copy %s: %w", existing, err)
+		}
+	}
+	destDir := filepath.Join(dir, s.Name)
+	if err := skill.CopyDir(s.Path, destDir, opts); err != nil {
+		return "", fmt.Errorf("copy failed: %w", err)
+	}
+	return destDir, nil
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AlfonsSkills/SkillSync/internal/skill"
+	"github.com/AlfonsSkills/SkillSync/internal/target"
+)
+
+// targetSummary 将安装目标格式化为 "名称" 或 "名称 (skip)"
+func targetSummary(targets []skillTarget) []string {
+	var result []string
+	for _, st := range targets {
+		s := st.provider.DisplayName()
+		if st.skip != "" {
+			s += " (skip)"
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+func TestSkillTargetsSharedDir(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("CLAUDE_CONFIG_DIR", "")
+
+	// 直接创建 Provider，避免注册表缓存其他测试的 HOME
+	providers := []target.ToolProvider{
+		target.NewAgentsProvider(), target.NewGooseProvider(), target.NewAmpProvider(), target.NewClaudeProvider(),
+	}
+	providers = target.CollapseShared(providers, true, "")
+
+	tests := []struct {
+		name       string
+		compat     skill.Compatibility
+		want       []string
+		wantReason string // 共享目录跳过原因片段
+	}{
+		{
+			name: "compatible with every reader",
+			want: []string{"Agents / Goose AI / Amp", "Claude Code"},
+		},
+		{
+			name:       "excluded reader keeps the skill out of the shared dir",
+			compat:     skill.Compatibility{Exclude: []string{"goose"}},
+			want:       []string{"Agents / Amp (skip)", "Goose AI (skip)", "Claude Code"},
+			wantReason: "also loaded by Goose AI",
+		},
+		{
+			name:       "reader-only skill gets its own copy",
+			compat:     skill.Compatibility{Tools: []string{"goose"}},
+			want:       []string{"Agents / Amp (skip)", "Goose AI", "Claude Code (skip)"},
+			wantReason: "also loaded by Agents (shared), Amp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targets := skillTargets(providers, tt.compat, "")
+			got := targetSummary(targets)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Fatalf("skillTargets() = %v, want %v", got, tt.want)
+			}
+			if tt.wantReason != "" && !strings.Contains(targets[0].skip, tt.wantReason) {
+				t.Errorf("shared dir skip reason = %q, want it to contain %q", targets[0].skip, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestPlaceSkillReplacesRenamedCopy(t *testing.T) {
+	installDir := t.TempDir()
+	p, err := target.NewCustomProvider(target.CustomSpec{ID: "test-tool", GlobalDir: installDir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 旧版本以目录名安装，之后源仓库在 frontmatter 中将其命名为 review
+	old := filepath.Join(installDir, "code-review")
+	writeTestSkill(t, old, "review")
+	src := filepath.Join(t.TempDir(), "code-review")
+	writeTestSkill(t, src, "review")
+
+	destDir, err := placeSkill(p, skill.LoadSkillInfo(src, "code-review"), skill.CopyOptions{}, categoryOptions{}, "")
+	if err != nil {
+		t.Fatalf("placeSkill() error = %v", err)
+	}
+	if want := filepath.Join(installDir, "review"); destDir != want {
+		t.Errorf("placeSkill() = %q, want %q", destDir, want)
+	}
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Errorf("old copy %s was not removed", old)
+	}
+	if got := skill.FindSkillDirs(installDir, "review"); len(got) != 1 {
+		t.Errorf("installed copies = %v, want exactly one", got)
+	}
+}
+
+// writeTestSkill 创建 frontmatter 名称为 name 的 skill 目录
+func writeTestSkill(t *testing.T, dir, name string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: " + name + "\n---\n\nBody\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCompatRuleIsConsistent(t *testing.T) {
+	shared := t.TempDir()
+	var providers []target.ToolProvider
+	for _, id := range []string{"tool-a", "tool-b"} {
+		p, err := target.NewCustomProvider(target.CustomSpec{ID: id, GlobalDir: shared})
+		if err != nil {
+			t.Fatal(err)
+		}
+		providers = append(providers, p)
+	}
+	merged := target.GroupByDir(providers, "")
+	if len(merged) != 1 {
+		t.Fatalf("GroupByDir() returned %d providers, want 1", len(merged))
+	}
+
+	tests := []struct {
+		name   string
+		compat skill.Compatibility
+		allow  bool
+	}{
+		{"unrestricted", skill.Compatibility{}, true},
+		{"every member allowed", skill.Compatibility{Tools: []string{"tool-a", "tool-b"}}, true},
+		{"one member excluded", skill.Compatibility{Exclude: []string{"tool-b"}}, false},
+		{"one member not in allow-list", skill.Compatibility{Tools: []string{"tool-a"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := skill.SkillInfo{Name: "demo", Compat: tt.compat}
+			allowed, _ := allowsProvider(tt.compat, merged[0])
+			picked := len(incompatibleSkills(merged[0], []skill.SkillInfo{s})) == 0
+			installed := skillTargets(merged, tt.compat, "")[0].skip == ""
+			if allowed != tt.allow || picked != tt.allow || installed != tt.allow {
+				t.Errorf("allowsProvider = %v, picker = %v, install = %v, want all %v", allowed, picked, installed, tt.allow)
+			}
+		})
+	}
+}
+
+func TestUnknownCompatTools(t *testing.T) {
+	compat := skill.Compatibility{Tools: []string{"claude", "cluade", "claude-code"}, Exclude: []string{"zzzzzz"}}
+	got := unknownCompatTools(compat)
+	want := []string{
+		`compatibility lists unknown tool "cluade", did you mean claude?`,
+		`compatibility lists alias "claude-code", use the tool id claude instead`,
+		`compatibility lists unknown tool "zzzzzz"`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unknownCompatTools() = %q, want %q", got, want)
+	}
+}
@@ -2,13 +2,16 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/fatih/color"
 
+	"github.com/AlfonsSkills/SkillSync/internal/export"
 	"github.com/AlfonsSkills/SkillSync/internal/project"
+	"github.com/AlfonsSkills/SkillSync/internal/scan"
 	"github.com/AlfonsSkills/SkillSync/internal/skill"
 	"github.com/AlfonsSkills/SkillSync/internal/target"
 )
@@ -23,50 +26,338 @@ type InteractiveContext struct {
 
 // resolveTargetProviders 解析或交互选择目标工具
 // 如果 targetFlags 为空且未显式指定，显示多选框让用户选择
+// skills 为待安装的 skill，用于按兼容性声明过滤或提示目标工具
+// 共享同一目录的工具（如 Copilot 与 VSCode）合并为一项，每个目录只安装一次
 // explicitlySet: 用户是否通过 --target 显式指定了值
-func resolveTargetProviders(targetFlags []string) ([]target.ToolProvider, bool, error) {
+func resolveTargetProviders(targetFlags []string, skills []skill.SkillInfo) ([]target.ToolProvider, bool, error) {
+	warnUnknownCompatTools(skills)
+
+	// 共享目录按当前项目判断，不在项目中时仅比较全局目录
+	projectRoot, _ := project.FindProjectRoot()
+
 	// 如果显式指定了 target，直接解析
 	if len(targetFlags) > 0 {
 		providers, err := target.ParseProviders(targetFlags)
 		if err != nil {
 			return nil, true, err
 		}
+		providers = target.GroupByDir(providers, projectRoot)
 		// 显示已选择的工具
 		color.Cyan("🎯 Target tools:\n")
 		for _, p := range providers {
 			color.White("   • %s\n", p.DisplayName())
+			if skipped := incompatibleSkills(p, skills); len(skipped) > 0 {
+				color.Yellow("     ⚠ Skipped (incompatible): %s\n", strings.Join(skipped, ", "))
+			}
 		}
 		fmt.Println()
 		return providers, true, nil
 	}
 
-	// 未指定，显示交互式多选
-	allProviders := target.AllProviders()
+	// 未指定，显示交互式多选（过滤掉没有任何兼容 skill 的工具）
+	// 默认仅显示检测到已安装的工具与来源仓库推荐的工具；预选推荐的工具，未推荐时预选已安装的工具
+	recommended := recommendedTargets(skills)
+	var candidates []target.ToolProvider
+	var labels []string
+	detected := make(map[target.ToolType]bool)
+	for _, p := range target.AllProviders() {
+		skipped := incompatibleSkills(p, skills)
+		if len(skills) > 0 && len(skipped) == len(skills) {
+			continue
+		}
+		detected[p.Type()] = target.IsDetected(p)
+		candidates = append(candidates, p)
+		if len(skipped) > 0 {
+			labels = append(labels, fmt.Sprintf("%s (skips: %s)", p.DisplayName(), strings.Join(skipped, ", ")))
+		} else {
+			labels = append(labels, p.DisplayName())
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, false, fmt.Errorf("no target tool is compatible with the selected skills")
+	}
+
+	chosen := make(map[target.ToolType]bool)
+	for _, p := range candidates {
+		if len(recommended) > 0 {
+			chosen[p.Type()] = slices.Contains(recommended, p.Type().String())
+		} else {
+			chosen[p.Type()] = detected[p.Type()]
+		}
+	}
+
+	// 推荐的工具即使未检测到也保持可见，避免被默认隐藏
+	visibleByDefault := func(p target.ToolProvider) bool {
+		return detected[p.Type()] || slices.Contains(recommended, p.Type().String())
+	}
+	showAll := !slices.ContainsFunc(candidates, visibleByDefault)
+	for {
+		var visible []target.ToolProvider
+		var options []string
+		var defaults []int
+		hidden := 0
+		for i, p := range candidates {
+			if !showAll && !visibleByDefault(p) {
+				hidden++
+				continue
+			}
+			label := labels[i]
+			if !detected[p.Type()] {
+				label += color.HiBlackString(" (not detected)")
+			}
+			if chosen[p.Type()] {
+				defaults = append(defaults, len(visible))
+			}
+			visible = append(visible, p)
+			options = append(options, label)
+		}
+		if hidden > 0 {
+			options = append(options, color.HiBlackString("➕ Show %d undetected tool(s)", hidden))
+		}
+
+		var selectedIndices []int
+		prompt := &survey.MultiSelect{
+			Message:  "Select target tools:",
+			Options:  options,
+			Default:  defaults,
+			PageSize: 10,
+		}
+		if err := survey.AskOne(prompt, &selectedIndices); err != nil {
+			return nil, false, fmt.Errorf("selection cancelled: %w", err)
+		}
+
+		// 关键步骤：选中切换项时保留已选工具，展开全部工具重新选择
+		toggled := false
+		for _, p := range visible {
+			chosen[p.Type()] = false
+		}
+		for _, idx := range selectedIndices {
+			if idx == len(visible) {
+				toggled = true
+				continue
+			}
+			chosen[visible[idx].Type()] = true
+		}
+		if toggled {
+			showAll = true
+			continue
+		}
+
+		var selectedProviders []target.ToolProvider
+		for _, p := range visible {
+			if chosen[p.Type()] {
+				selectedProviders = append(selectedProviders, p)
+			}
+		}
+		if len(selectedProviders) == 0 {
+			return nil, false, fmt.Errorf("no tools selected")
+		}
+		return target.GroupByDir(selectedProviders, projectRoot), false, nil
+	}
+}
+
+// selectSkills 交互选择要安装的 skill
+// 有名称的分组（skillsync.yaml 分组或插件）显示为一个可选项，选中即安装其全部 skill，其后缩进列出组内 skill；
+// 清单声明的默认项预先选中，隐藏的 skill 不显示
+// 返回: 按路径去重后的已选 skill，保持清单顺序
+func selectSkills(groups []skill.SkillGroup) ([]skill.SkillInfo, error) {
+	type option struct {
+		group int // 所属分组下标
+		skill int // 组内 skill 下标，-1 表示整个分组
+	}
 	var options []string
-	for _, p := range allProviders {
-		options = append(options, p.DisplayName())
+	var entries []option
+	var defaults []int
+	magenta := color.New(color.FgMagenta, color.Bold).SprintFunc()
+	for gi, g := range groups {
+		indent := ""
+		if g.Name != "" {
+			label := fmt.Sprintf("📦 %s (%d skill(s))", magenta(g.Name), len(g.Skills))
+			if g.Description != "" {
+				label += " - " + g.Description
+			}
+			if g.Default {
+				defaults = append(defaults, len(options))
+			}
+			options = append(options, label)
+			entries = append(entries, option{group: gi, skill: -1})
+			indent = "   "
+		}
+		for si, s := range g.Skills {
+			if s.Hidden {
+				continue
+			}
+			if s.Default && !g.Default {
+				defaults = append(defaults, len(options))
+			}
+			options = append(options, indent+skillOption(s))
+			entries = append(entries, option{group: gi, skill: si})
+		}
+	}
+	if len(options) == 0 {
+		return nil, nil
 	}
 
 	var selectedIndices []int
 	prompt := &survey.MultiSelect{
-		Message:  "Select target tools:",
+		Message:  "Select skills to install:",
 		Options:  options,
-		PageSize: 5,
+		Default:  defaults,
+		PageSize: 10,
+	}
+	if hasNamedGroups(groups) {
+		prompt.Message = "Select groups or skills to install:"
+		prompt.PageSize = 15
 	}
 	if err := survey.AskOne(prompt, &selectedIndices); err != nil {
-		return nil, false, fmt.Errorf("selection cancelled: %w", err)
+		return nil, fmt.Errorf("selection cancelled: %w", err)
 	}
 
-	if len(selectedIndices) == 0 {
-		return nil, false, fmt.Errorf("no tools selected")
+	chosen := make(map[string]bool)
+	for _, idx := range selectedIndices {
+		e := entries[idx]
+		if e.skill >= 0 {
+			chosen[groups[e.group].Skills[e.skill].Path] = true
+			continue
+		}
+		for _, s := range groups[e.group].Skills {
+			chosen[s.Path] = true
+		}
 	}
 
-	var selectedProviders []target.ToolProvider
-	for _, idx := range selectedIndices {
-		selectedProviders = append(selectedProviders, allProviders[idx])
+	var selected []skill.SkillInfo
+	for _, s := range skill.FlattenGroups(groups) {
+		if chosen[s.Path] {
+			selected = append(selected, s)
+		}
 	}
+	return selected, nil
+}
 
-	return selectedProviders, false, nil
+// skillOption 格式化选择列表中的 skill：名称、实验性标记与描述
+func skillOption(s skill.SkillInfo) string {
+	label := color.New(color.FgCyan).Sprint(s.Name)
+	if s.Experimental {
+		label += color.New(color.FgYellow).Sprint(" (experimental)")
+	}
+	if s.Desc != "" {
+		label += " - " + s.Desc
+	}
+	return label
+}
+
+// hasNamedGroups 判断是否存在有名称的分组（来自清单）
+func hasNamedGroups(groups []skill.SkillGroup) bool {
+	return countNamedGroups(groups) > 0
+}
+
+// countNamedGroups 统计有名称的分组数量
+func countNamedGroups(groups []skill.SkillGroup) int {
+	n := 0
+	for _, g := range groups {
+		if g.Name != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// skillsInGroups 返回指定分组中的全部 skill（--group）
+// 分组名不存在时报错并列出可用分组
+func skillsInGroups(groups []skill.SkillGroup, names []string) ([]skill.SkillInfo, error) {
+	var picked []skill.SkillGroup
+	var available []string
+	for _, g := range groups {
+		if g.Name != "" {
+			available = append(available, g.Name)
+		}
+	}
+	for _, name := range names {
+		idx := slices.IndexFunc(groups, func(g skill.SkillGroup) bool { return g.Name != "" && g.Name == name })
+		if idx < 0 {
+			if len(available) == 0 {
+				return nil, fmt.Errorf("unknown group: %s (repository declares no groups)", name)
+			}
+			return nil, fmt.Errorf("unknown group: %s (available: %s)", name, strings.Join(available, ", "))
+		}
+		picked = append(picked, groups[idx])
+	}
+	return skill.FlattenGroups(picked), nil
+}
+
+// recommendedTargets 汇总 skill 声明的推荐目标工具（来自 skillsync.yaml）
+func recommendedTargets(skills []skill.SkillInfo) []string {
+	var result []string
+	for _, s := range skills {
+		for _, t := range s.Targets {
+			if !slices.Contains(result, t) {
+				result = append(result, t)
+			}
+		}
+	}
+	return result
+}
+
+// allowsProvider 判断兼容性声明是否允许安装到目标工具
+// 合并的工具共同加载同一目录，需所有成员都兼容
+func allowsProvider(compat skill.Compatibility, p target.ToolProvider) (bool, string) {
+	members := target.Members(p)
+	var rejected []string
+	var reason string
+	for _, m := range members {
+		if ok, r := compat.Allows(m.Type().String()); !ok {
+			rejected = append(rejected, m.DisplayName())
+			reason = r
+		}
+	}
+	switch {
+	case len(rejected) == 0:
+		return true, ""
+	case len(members) == 1:
+		return false, reason
+	default:
+		return false, fmt.Sprintf("shared directory is also loaded by %s (%s)", strings.Join(rejected, ", "), reason)
+	}
+}
+
+// warnUnknownCompatTools 提示兼容性声明中未注册的工具标识
+// 拼写错误的白名单会让目标工具被静默跳过，黑名单则不生效
+func warnUnknownCompatTools(skills []skill.SkillInfo) {
+	for _, s := range skills {
+		for _, msg := range unknownCompatTools(s.Compat) {
+			color.Yellow("⚠ %s: %s\n", s.Name, msg)
+		}
+	}
+}
+
+// unknownCompatTools 返回兼容性声明中每个未注册工具标识的提示
+// 别名不会被匹配，提示改用工具标识；拼写接近已知工具时给出建议
+func unknownCompatTools(compat skill.Compatibility) []string {
+	var msgs []string
+	for _, name := range compat.ToolNames() {
+		if _, err := target.GetProvider(target.ToolType(name)); err == nil {
+			continue
+		}
+		if p, err := target.GetProviderByName(name); err == nil {
+			msgs = append(msgs, fmt.Sprintf("compatibility lists alias %q, use the tool id %s instead", name, p.Type()))
+		} else if suggestion := target.Suggest(name); suggestion != "" {
+			msgs = append(msgs, fmt.Sprintf("compatibility lists unknown tool %q, did you mean %s?", name, suggestion))
+		} else {
+			msgs = append(msgs, fmt.Sprintf("compatibility lists unknown tool %q", name))
+		}
+	}
+	return msgs
+}
+
+// incompatibleSkills 返回声明不兼容指定工具的 skill 名称
+func incompatibleSkills(p target.ToolProvider, skills []skill.SkillInfo) []string {
+	var names []string
+	for _, s := range skills {
+		if ok, _ := allowsProvider(s.Compat, p); !ok {
+			names = append(names, s.Name)
+		}
+	}
+	return names
 }
 
 // resolveLocalInstall 解析或交互选择是否安装到项目目录
@@ -116,23 +407,68 @@ func resolveLocalInstall(localFlag bool) (bool, bool, string, error) {
 }
 
 // showInstallPreview 显示安装路径预览
-func showInstallPreview(skills []skill.SkillInfo, providers []target.ToolProvider, installGlobal, installLocal bool, projectRoot string) {
+// deps 为依赖解析得到的额外 skill（已包含在 skills 中），单独列出来源
+// copyOpts 为每个 skill 的拷贝选项，用于统计被排除的文件
+// category 为有分类工具的全局安装位置，写入只读分类的工具显示为跳过
+// rulesFormat 为 true 时支持规则的工具显示规则文件路径
+func showInstallPreview(skills []skill.SkillInfo, deps []skill.ResolvedDependency, copyOpts map[string]skill.CopyOptions, providers []target.ToolProvider, category categoryOptions, rulesFormat bool, installGlobal, installLocal bool, projectRoot string) {
+	if len(deps) > 0 {
+		color.Cyan("🔗 Dependencies (%d extra skill(s)):\n", len(deps))
+		for _, d := range deps {
+			source := d.Source
+			if d.Ref != "" {
+				source += "@" + d.Ref
+			}
+			color.White("   • %s ← required by %s\n", color.New(color.FgCyan).Sprint(d.Info.Name), d.RequiredBy)
+			color.HiCyan("     from %s\n", source)
+		}
+		fmt.Println()
+	}
+
 	color.Cyan("📍 Installation preview:\n")
 
 	for _, s := range skills {
 		color.White("   Skill: %s\n", color.New(color.FgCyan).Sprint(s.Name))
+		if stats, err := skill.CountExcluded(s.Path, copyOpts[s.Path]); err == nil && stats.Files > 0 {
+			color.HiBlack("     🚫 Excluded: %d file(s), %s\n", stats.Files, formatBytes(stats.Bytes))
+		}
 
-		if installGlobal {
+		// 按兼容性声明拆分目标工具
+		localRoot := ""
+		if installLocal {
+			localRoot = projectRoot
+		}
+		var compatible []target.ToolProvider
+		for _, st := range skillTargets(providers, s.Compat, localRoot) {
+			if st.skip != "" {
+				color.Yellow("     ⏭ %s: skipped (%s)\n", st.provider.DisplayName(), st.skip)
+				continue
+			}
+			compatible = append(compatible, st.provider)
+		}
+
+		if installGlobal && len(compatible) > 0 {
 			color.White("   Global:\n")
-			for _, p := range providers {
-				dir, _ := p.GlobalInstallDir()
+			for _, p := range compatible {
+				dir, err := categoryInstallDir(p, s.Name, category)
+				if err != nil {
+					color.Yellow("     ⏭ %s: %v\n", p.DisplayName(), err)
+					continue
+				}
+				if dir == "" {
+					dir, _ = p.GlobalInstallDir()
+				}
 				color.White("     📁 %s/%s\n", dir, s.Name)
 			}
 		}
 
-		if installLocal && projectRoot != "" {
+		if installLocal && projectRoot != "" && len(compatible) > 0 {
 			color.White("   Project:\n")
-			for _, p := range providers {
+			for _, p := range compatible {
+				if rp, ok := p.(target.RulesProvider); ok && rulesFormat {
+					color.White("     📄 %s/%s%s\n", rp.LocalRulesDir(projectRoot), s.Name, export.CursorRuleExt)
+					continue
+				}
 				dir := p.LocalSkillsDir(projectRoot)
 				color.White("     📁 %s/%s\n", dir, s.Name)
 			}
@@ -141,16 +477,103 @@ func showInstallPreview(skills []skill.SkillInfo, providers []target.ToolProvide
 	fmt.Println()
 }
 
+// formatBytes 格式化字节数
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
+
+// scanInstallSkills 对待安装的 skill 执行安全扫描
+func scanInstallSkills(skills []skill.SkillInfo, copyOpts map[string]skill.CopyOptions) ([]scan.Report, error) {
+	reports := make([]scan.Report, 0, len(skills))
+	for _, s := range skills {
+		report, err := scan.ScanSkill(s, copyOpts[s.Path])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.Name, err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// maxSeverity 返回所有报告中的最高风险等级
+func maxSeverity(reports []scan.Report) scan.Severity {
+	max := scan.SeverityNone
+	for _, r := range reports {
+		if m := r.Max(); m > max {
+			max = m
+		}
+	}
+	return max
+}
+
+// showSecurityReport 显示安全扫描结果
+func showSecurityReport(reports []scan.Report) {
+	total := 0
+	for _, r := range reports {
+		total += len(r.Findings)
+	}
+	if total == 0 {
+		color.Green("🛡️  Security scan: no issues found\n\n")
+		return
+	}
+
+	color.Cyan("🛡️  Security scan (%d issue(s)):\n", total)
+	for _, r := range reports {
+		if len(r.Findings) == 0 {
+			continue
+		}
+		color.White("   Skill: %s\n", color.New(color.FgCyan).Sprint(r.Skill))
+		for _, f := range r.Findings {
+			label := severityColor(f.Severity).Sprintf("[%s]", strings.ToUpper(f.Severity.String()))
+			color.White("     %s %s: %s (%s)\n", label, f.Location(), f.Message, f.Rule)
+		}
+	}
+	fmt.Println()
+}
+
+// severityColor 返回风险等级对应的颜色
+func severityColor(s scan.Severity) *color.Color {
+	switch s {
+	case scan.SeverityHigh:
+		return color.New(color.FgRed, color.Bold)
+	case scan.SeverityMedium:
+		return color.New(color.FgYellow)
+	default:
+		return color.New(color.FgHiBlack)
+	}
+}
+
 // showRemovePreview 显示删除路径预览
-func showRemovePreview(skillName string, providers []target.ToolProvider, removeGlobal, removeLocal bool, projectRoot string) {
+// allowProtected 为 true 时只读分类中的副本同样列为待删除
+func showRemovePreview(skillName string, providers []target.ToolProvider, removeGlobal, removeLocal bool, projectRoot string, allowProtected bool) {
 	color.Cyan("🗑️  Removal preview:\n")
 	color.White("   Skill: %s\n", color.New(color.FgCyan).Sprint(skillName))
 
 	if removeGlobal {
 		color.White("   Global:\n")
 		for _, p := range providers {
-			dir, _ := p.GlobalInstallDir()
-			color.White("     📁 %s/%s\n", dir, skillName)
+			copies := findInstalledSkill(p, skillName)
+			if len(copies) == 0 {
+				dir, _ := p.GlobalInstallDir()
+				copies = []installedCopy{{path: filepath.Join(dir, skillName)}}
+			}
+			// 同一 skill 存在于多个分类时逐一列出
+			for _, c := range copies {
+				if target.IsProtectedCategory(p, c.category) && !allowProtected {
+					color.Yellow("     🔒 %s (read-only, kept)\n", c.path)
+					continue
+				}
+				color.White("     📁 %s\n", c.path)
+			}
 		}
 	}
 
@@ -158,12 +581,23 @@ func showRemovePreview(skillName string, providers []target.ToolProvider, remove
 		color.White("   Project:\n")
 		for _, p := range providers {
 			dir := p.LocalSkillsDir(projectRoot)
-			color.White("     📁 %s/%s\n", dir, skillName)
+			for _, path := range installedSkillPaths(dir, skillName) {
+				color.White("     📁 %s\n", path)
+			}
 		}
 	}
 	fmt.Println()
 }
 
+// installedSkillPaths 返回已安装 skill 的所有实际路径
+// 按目录名或 frontmatter name 匹配，未找到时回退为 dir/skillName
+func installedSkillPaths(dir, skillName string) []string {
+	if paths := skill.FindSkillDirs(dir, skillName); len(paths) > 0 {
+		return paths
+	}
+	return []string{filepath.Join(dir, skillName)}
+}
+
 // resolveRemoveScope 解析或交互选择删除范围
 // 与 resolveLocalInstall 类似，但用于 remove 命令
 func resolveRemoveScope(localFlag bool) (bool, bool, string, error) {
@@ -205,19 +639,14 @@ func resolveRemoveScope(localFlag bool) (bool, bool, string, error) {
 	return true, false, "", nil
 }
 
-// checkSkillExistsInProviders 检查 skill 在哪些工具的全局目录中存在
-// 返回存在该 skill 的 providers 列表
-func checkSkillExistsInProviders(skillName string) []target.ToolProvider {
-	allProviders := target.AllProviders()
+// checkSkillExistsInProviders 检查 skill 在哪些工具的全局目录（含各分类）中存在
+// 返回存在该 skill 的 providers 列表（共享目录的工具合并为一项）
+func checkSkillExistsInProviders(skillName, projectRoot string) []target.ToolProvider {
+	allProviders := target.GroupByDir(target.AllProviders(), projectRoot)
 	var existingProviders []target.ToolProvider
 
 	for _, p := range allProviders {
-		globalDir, err := p.GlobalInstallDir()
-		if err != nil {
-			continue
-		}
-		skillPath := filepath.Join(globalDir, skillName)
-		if _, err := os.Stat(skillPath); err == nil {
+		if len(findInstalledSkill(p, skillName)) > 0 {
 			existingProviders = append(existingProviders, p)
 		}
 	}
@@ -234,8 +663,7 @@ func checkSkillExistsInProject(skillName string, providers []target.ToolProvider
 
 	for _, p := range providers {
 		localDir := p.LocalSkillsDir(projectRoot)
-		skillPath := filepath.Join(localDir, skillName)
-		if _, err := os.Stat(skillPath); err == nil {
+		if skill.FindSkillDir(localDir, skillName) != "" {
 			return true
 		}
 	}
@@ -246,12 +674,15 @@ func checkSkillExistsInProject(skillName string, providers []target.ToolProvider
 // resolveTargetProvidersForRemove 为 remove 命令解析目标工具
 // 仅显示存在 skill 的工具选项
 func resolveTargetProvidersForRemove(skillName string, targetFlags []string) ([]target.ToolProvider, bool, error) {
+	projectRoot, _ := project.FindProjectRoot()
+
 	// 如果显式指定了 target，直接解析（不过滤）
 	if len(targetFlags) > 0 {
 		providers, err := target.ParseProviders(targetFlags)
 		if err != nil {
 			return nil, true, err
 		}
+		providers = target.GroupByDir(providers, projectRoot)
 		color.Cyan("🎯 Target tools:\n")
 		for _, p := range providers {
 			color.White("   • %s\n", p.DisplayName())
@@ -261,7 +692,7 @@ func resolveTargetProvidersForRemove(skillName string, targetFlags []string) ([]
 	}
 
 	// 检查 skill 在哪些工具中存在
-	existingProviders := checkSkillExistsInProviders(skillName)
+	existingProviders := checkSkillExistsInProviders(skillName, projectRoot)
 	if len(existingProviders) == 0 {
 		return nil, false, fmt.Errorf("skill '%s' not found in any tool's global directory", skillName)
 	}
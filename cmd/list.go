@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -31,7 +32,8 @@ func init() {
 
 // LocalSkill represents a locally discovered skill
 type LocalSkill struct {
-	Name        string
+	Name        string // Canonical name (frontmatter name, falls back to directory name)
+	DirName     string // Directory name on disk
 	Path        string
 	Provider    target.ToolProvider // 使用 Provider 替代 Target
 	Valid       bool                // Contains SKILL.md
@@ -45,6 +47,9 @@ func runList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	// 共享目录的工具只扫描一次
+	projectRoot, _ := findProjectRoot()
+	providers = target.GroupByDir(providers, projectRoot)
 
 	// Scan skills in target directories (global)
 	var allSkills []LocalSkill
@@ -157,24 +162,83 @@ func printSkill(s LocalSkill) {
 	yellow := color.New(color.FgYellow).SprintFunc()
 	white := color.New(color.FgWhite).SprintFunc()
 
+	// Show the on-disk directory when it differs from the canonical name
+	name := s.Name
+	if s.DirName != "" && s.DirName != s.Name {
+		name = fmt.Sprintf("%s (dir: %s)", s.Name, s.DirName)
+	}
+
 	if s.Valid {
 		if desc != "" {
-			fmt.Printf("%s%s%s\n", prefix, green("✓ "+s.Name), white(" - "+desc))
+			fmt.Printf("%s%s%s\n", prefix, green("✓ "+name), white(" - "+desc))
 		} else {
-			fmt.Printf("%s%s\n", prefix, green("✓ "+s.Name))
+			fmt.Printf("%s%s\n", prefix, green("✓ "+name))
 		}
 	} else {
 		fmt.Printf("%s%s\n", prefix, yellow("⚠ "+s.Name+" (missing SKILL.md)"))
 	}
 }
 
+// newLocalSkill builds a LocalSkill from an installed skill directory
+// The canonical name comes from SKILL.md frontmatter, falling back to the directory name
+func newLocalSkill(entryPath string, p target.ToolProvider, category string) LocalSkill {
+	dirName := filepath.Base(entryPath)
+	ls := LocalSkill{
+		Name:     dirName,
+		DirName:  dirName,
+		Path:     entryPath,
+		Provider: p,
+		Category: category,
+	}
+	if meta, err := skill.ReadMetadata(entryPath); err == nil {
+		ls.Valid = true
+		ls.Name = skill.ResolveSkillName(meta, dirName)
+		ls.Description = meta.Description
+	}
+	return ls
+}
+
+// managedSkills 由自行管理安装的工具（外部插件）列出 skill
+// 返回: skill 列表，以及插件是否处理了请求（不支持时调用方回退为扫描目录）
+func managedSkills(p target.ToolProvider, projectRoot, category string) ([]LocalSkill, bool, error) {
+	sm, ok := p.(target.SkillManager)
+	if !ok {
+		return nil, false, nil
+	}
+	items, err := sm.ListSkills(projectRoot)
+	if errors.Is(err, target.ErrUnsupported) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, true, err
+	}
+
+	var skills []LocalSkill
+	for _, item := range items {
+		ls := LocalSkill{Name: item.Name, DirName: item.Name, Provider: p, Category: category, Valid: true, Description: item.Description}
+		if item.Path != "" && skill.ValidateSkillDir(item.Path) == nil {
+			ls = newLocalSkill(item.Path, p, category)
+		}
+		skills = append(skills, ls)
+	}
+	return skills, true, nil
+}
+
 // scanLocalSkillsWithProvider scans skills in the specified provider's directory
 func scanLocalSkillsWithProvider(p target.ToolProvider) ([]LocalSkill, error) {
+	if skills, handled, err := managedSkills(p, "", ""); handled {
+		return skills, err
+	}
+
 	skillsDir, err := p.GlobalSkillsDir()
 	if err != nil {
 		return nil, err
 	}
+	return scanSkillsDir(skillsDir, p)
+}
 
+// scanSkillsDir scans a skills directory, descending into the provider's category subdirectories
+func scanSkillsDir(skillsDir string, p target.ToolProvider) ([]LocalSkill, error) {
 	// Check if directory exists
 	if _, err := os.Stat(skillsDir); os.IsNotExist(err) {
 		return nil, nil // Directory doesn't exist, return empty list
@@ -196,8 +260,8 @@ func scanLocalSkillsWithProvider(p target.ToolProvider) ([]LocalSkill, error) {
 	}
 
 	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue // Skip files, only process directories
+		if !skill.IsDirEntry(skillsDir, entry) {
+			continue // Skip files, only process directories (linked skills included)
 		}
 
 		name := entry.Name()
@@ -220,14 +284,7 @@ func scanLocalSkillsWithProvider(p target.ToolProvider) ([]LocalSkill, error) {
 				continue
 			}
 
-			skills = append(skills, LocalSkill{
-				Name:        name,
-				Path:        entryPath,
-				Provider:    p,
-				Valid:       valid,
-				Category:    "",
-				Description: skill.ReadSkillDescription(entryPath),
-			})
+			skills = append(skills, newLocalSkill(entryPath, p, ""))
 		}
 	}
 
@@ -243,7 +300,7 @@ func scanCategoryDirWithProvider(dir, category string, p target.ToolProvider) ([
 
 	var skills []LocalSkill
 	for _, entry := range entries {
-		if !entry.IsDir() {
+		if !skill.IsDirEntry(dir, entry) {
 			continue
 		}
 
@@ -255,16 +312,7 @@ func scanCategoryDirWithProvider(dir, category string, p target.ToolProvider) ([
 		}
 
 		entryPath := filepath.Join(dir, name)
-		valid := skill.ValidateSkillDir(entryPath) == nil
-
-		skills = append(skills, LocalSkill{
-			Name:        name,
-			Path:        entryPath,
-			Provider:    p,
-			Valid:       valid,
-			Category:    category,
-			Description: skill.ReadSkillDescription(entryPath),
-		})
+		skills = append(skills, newLocalSkill(entryPath, p, category))
 	}
 
 	return skills, nil
@@ -280,7 +328,15 @@ func scanProjectSkillsWithProviders(providers []target.ToolProvider) []LocalSkil
 		return nil
 	}
 
+	category := fmt.Sprintf("project:%s", filepath.Base(projectRoot))
 	for _, p := range providers {
+		if skills, handled, err := managedSkills(p, projectRoot, category); handled {
+			if err == nil {
+				projectSkills = append(projectSkills, skills...)
+			}
+			continue
+		}
+
 		// Get project-local skills directory using Provider interface
 		skillsDir := p.LocalSkillsDir(projectRoot)
 
@@ -296,7 +352,7 @@ func scanProjectSkillsWithProviders(providers []target.ToolProvider) []LocalSkil
 		}
 
 		for _, entry := range entries {
-			if !entry.IsDir() {
+			if !skill.IsDirEntry(skillsDir, entry) {
 				continue
 			}
 
@@ -306,16 +362,7 @@ func scanProjectSkillsWithProviders(providers []target.ToolProvider) []LocalSkil
 			}
 
 			entryPath := filepath.Join(skillsDir, name)
-			valid := skill.ValidateSkillDir(entryPath) == nil
-
-			projectSkills = append(projectSkills, LocalSkill{
-				Name:        name,
-				Path:        entryPath,
-				Provider:    p,
-				Valid:       valid,
-				Category:    fmt.Sprintf("project:%s", filepath.Base(projectRoot)),
-				Description: skill.ReadSkillDescription(entryPath),
-			})
+			projectSkills = append(projectSkills, newLocalSkill(entryPath, p, category))
 		}
 	}
 
@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/AlfonsSkills/SkillSync/internal/git"
+	"github.com/AlfonsSkills/SkillSync/internal/skill"
+	"github.com/AlfonsSkills/SkillSync/internal/target"
+)
+
+var (
+	newDescription  string
+	newTemplate     string
+	newTemplateRepo string
+	newOutputDir    string
+	newFolders      []string
+	newLink         bool
+	newLocal        bool
+)
+
+// newCmd new command
+var newCmd = &cobra.Command{
+	Use:   "new <skill-name>",
+	Short: "Create a new skill from a template",
+	Long: `Create a new spec-compliant skill directory with SKILL.md frontmatter.
+
+Built-in templates: ` + strings.Join(skill.BuiltinTemplateNames(), ", ") + `
+
+User templates are loaded from a Git repository. The template is a directory
+containing SKILL.md; {{name}}, {{title}} and {{description}} placeholders are
+replaced in all text files.
+
+--link symlinks the skill into the same directory install would use (for Codex,
+the category that already holds it, otherwise public). Plugin-managed tools are
+skipped because they only accept copies.
+
+Examples:
+  skillsync new pdf-tools
+  skillsync new pdf-tools -d "Extract text and tables from PDFs" --folders scripts,references
+  skillsync new pdf-tools --template script
+  skillsync new pdf-tools --template-repo AlfonsSkills/skill-templates --template python-tool
+  skillsync new pdf-tools --link -t claude,codex`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNew,
+}
+
+func init() {
+	rootCmd.AddCommand(newCmd)
+	newCmd.Flags().StringVarP(&newDescription, "description", "d", "", "Skill description (prompted if omitted)")
+	newCmd.Flags().StringVar(&newTemplate, "template", "basic", "Template name (built-in, or directory name in --template-repo)")
+	newCmd.Flags().StringVar(&newTemplateRepo, "template-repo", "", "Git repository containing user templates")
+	newCmd.Flags().StringVarP(&newOutputDir, "output", "o", ".", "Parent directory for the new skill")
+	newCmd.Flags().StringSliceVar(&newFolders, "folders", nil, "Optional folders to create (scripts, references, assets)")
+	newCmd.Flags().BoolVar(&newLink, "link", false, "Link the new skill into target tools for development")
+	newCmd.Flags().BoolVarP(&newLocal, "local", "l", false, "With --link, link into project-local skills directories only")
+}
+
+func runNew(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	// Step 1: Validate name and options
+	if err := skill.ValidateName(name); err != nil {
+		color.Red("❌ %v\n", err)
+		return err
+	}
+	for _, folder := range newFolders {
+		if !isOptionalFolder(folder) {
+			return fmt.Errorf("unknown folder: %s, valid folders are: %s", folder, strings.Join(skill.OptionalFolders, ", "))
+		}
+	}
+
+	skillDir, err := filepath.Abs(filepath.Join(newOutputDir, name))
+	if err != nil {
+		return err
+	}
+
+	// Step 2: Ask for description if missing
+	if newDescription == "" {
+		prompt := &survey.Input{
+			Message: "Skill description (what it does and when to use it):",
+		}
+		if err := survey.AskOne(prompt, &newDescription, survey.WithValidator(survey.Required)); err != nil {
+			return fmt.Errorf("cancelled: %w", err)
+		}
+	}
+
+	opts := skill.ScaffoldOptions{
+		Name:        name,
+		Description: newDescription,
+		Dir:         skillDir,
+		Template:    newTemplate,
+		Folders:     newFolders,
+	}
+
+	// Step 3: Fetch user template repository if specified
+	if newTemplateRepo != "" {
+		fetcher := git.NewFetcher()
+		color.Cyan("📦 Fetching templates...\n")
+		color.White("   Source: %s\n\n", fetcher.NormalizeURL(newTemplateRepo))
+		tempDir, err := fetcher.CloneToTemp(newTemplateRepo)
+		if err != nil {
+			color.Red("❌ Clone failed: %v\n", err)
+			return err
+		}
+		defer os.RemoveAll(tempDir)
+
+		templateDir, err := findTemplateDir(tempDir, newTemplate)
+		if err != nil {
+			color.Red("❌ %v\n", err)
+			return err
+		}
+		opts.TemplateDir = templateDir
+	}
+
+	// Step 4: Generate skill
+	if err := skill.Scaffold(opts); err != nil {
+		color.Red("❌ Failed to create skill: %v\n", err)
+		return err
+	}
+	color.Green("✅ Created skill '%s': %s\n\n", name, skillDir)
+
+	if !newLink {
+		return nil
+	}
+
+	// Step 5: Link into target tools (dev mode)
+	info := skill.LoadSkillInfo(skillDir, name)
+	providers, _, err := resolveTargetProviders(targetFlags, []skill.SkillInfo{info})
+	if err != nil {
+		return err
+	}
+	linkGlobal, linkLocal, projectRoot, err := resolveLocalInstall(newLocal)
+	if err != nil {
+		return err
+	}
+	providers = applyInstallStrategy(providers, strategyCopy, linkGlobal, linkLocal, projectRoot)
+
+	localRoot := ""
+	if linkLocal {
+		localRoot = projectRoot
+	}
+	linked := 0
+	for _, st := range skillTargets(providers, info.Compat, localRoot) {
+		p := st.provider
+		if st.skip != "" {
+			color.Yellow("   ⏭ Skipping %s: %s\n", p.DisplayName(), st.skip)
+			continue
+		}
+		// 自行管理安装的工具（外部插件）只接收拷贝，无法链接到开发目录
+		if target.ManagesSkills(p) {
+			color.Yellow("   ⏭ Skipping %s: manages its own installs, use 'skillsync install' instead\n", p.DisplayName())
+			continue
+		}
+		// 链接位置与 install 一致：有分类的工具沿用已安装副本所在分类，否则使用默认安装目录
+		if linkGlobal {
+			if dir, err := ensureSkillParentDir(p, name, categoryOptions{}, ""); err != nil {
+				color.Yellow("   ⚠ Skipping %s (global): %v\n", p.DisplayName(), err)
+			} else if linkSkill(skillDir, filepath.Join(dir, name), p.DisplayName()) {
+				linked++
+			}
+		}
+		if linkLocal && projectRoot != "" {
+			if dir, err := ensureSkillParentDir(p, name, categoryOptions{}, projectRoot); err != nil {
+				color.Yellow("   ⚠ Skipping %s (project): %v\n", p.DisplayName(), err)
+			} else if linkSkill(skillDir, filepath.Join(dir, name), p.DisplayName()) {
+				linked++
+			}
+		}
+	}
+
+	color.Green("\n✅ Linked to %d location(s), edits in %s take effect immediately\n", linked, skillDir)
+	return nil
+}
+
+// linkSkill 创建指向开发目录的符号链接
+// 目标已存在时跳过，避免覆盖已安装的同名 skill
+func linkSkill(skillDir, linkPath, displayName string) bool {
+	if _, err := os.Lstat(linkPath); err == nil {
+		color.Yellow("   ⚠ %s: %s already exists, skipped\n", displayName, linkPath)
+		return false
+	}
+	if err := os.Symlink(skillDir, linkPath); err != nil {
+		color.Yellow("   ⚠ %s: link failed: %v\n", displayName, err)
+		return false
+	}
+	color.Green("   ✓ %s: %s -> %s\n", displayName, linkPath, skillDir)
+	return true
+}
+
+// findTemplateDir 在模板仓库中查找指定模板
+// 查找顺序：templates/<name>/、<name>/、任意目录名或 frontmatter name 匹配的 skill、仓库根目录
+func findTemplateDir(repoDir, name string) (string, error) {
+	for _, candidate := range []string{
+		filepath.Join(repoDir, "templates", name),
+		filepath.Join(repoDir, name),
+	} {
+		if skill.ValidateSkillDir(candidate) == nil {
+			return candidate, nil
+		}
+	}
+
+	templates, err := skill.ScanSkills(repoDir)
+	if err != nil {
+		return "", err
+	}
+	var available []string
+	for _, t := range templates {
+		if t.DirName == name || t.Name == name {
+			return t.Path, nil
+		}
+		available = append(available, t.DirName)
+	}
+
+	// 单模板仓库：根目录即模板
+	if len(templates) == 0 && skill.ValidateSkillDir(repoDir) == nil {
+		return repoDir, nil
+	}
+	return "", fmt.Errorf("template %q not found in repository, available templates: %s", name, strings.Join(available, ", "))
+}
+
+// isOptionalFolder 检查是否为规范定义的可选目录
+func isOptionalFolder(folder string) bool {
+	for _, f := range skill.OptionalFolders {
+		if f == folder {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/AlfonsSkills/SkillSync/internal/config"
+	"github.com/AlfonsSkills/SkillSync/internal/pack"
+	"github.com/AlfonsSkills/SkillSync/internal/skill"
+	"github.com/AlfonsSkills/SkillSync/internal/verify"
+)
+
+var (
+	packFormat   string
+	packOutput   string
+	packSignKey  string
+	packExclude  []string
+	packInclude  []string
+	packSymlinks string
+)
+
+// packCmd pack command
+var packCmd = &cobra.Command{
+	Use:   "pack <skill-dir>",
+	Short: "Build a distributable skill archive with checksums",
+	Long: `Pack a skill directory into a deterministic archive.
+
+Entries are sorted, modification times and permissions are normalized, and the
+same exclusions as install are applied, so identical content always produces an
+identical archive. A manifest with the file list, SHA-256 hashes and parsed
+SKILL.md metadata is written next to the archive (<archive>.manifest.json).
+Archives never contain symlinks: by default the content a link points to is
+packed, and --symlinks reject fails on any link instead. Links pointing outside
+the skill directory are always refused.
+With --sign-key a detached signature (<archive>.sig) is written as well, which
+install verifies according to the verify policy in the config file.
+
+Examples:
+  skillsync pack ./my-skill
+  skillsync pack ./my-skill --format zip
+  skillsync pack ./my-skill -o dist/my-skill-v1.0.0.tar.gz
+  skillsync pack ./my-skill --sign-key ~/.ssh/id_ed25519`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPack,
+}
+
+func init() {
+	rootCmd.AddCommand(packCmd)
+	packCmd.Flags().StringVarP(&packFormat, "format", "f", "tar.gz", "Archive format (tar.gz, zip)")
+	packCmd.Flags().StringVarP(&packOutput, "output", "o", "", "Output archive path (default: <name>.<format> in current directory)")
+	packCmd.Flags().StringSliceVar(&packExclude, "exclude", nil, "Exclude files matching gitignore-style patterns (repeatable)")
+	packCmd.Flags().StringSliceVar(&packInclude, "include", nil, "Re-include files excluded by .skillsyncignore or --exclude (repeatable)")
+	packCmd.Flags().StringVar(&packSymlinks, "symlinks", string(skill.SymlinkDereference), "Symlink handling: dereference or reject (links outside the skill are always refused)")
+	packCmd.Flags().StringVar(&packSignKey, "sign-key", "", "Sign the archive with an SSH private key or PEM ed25519 key")
+}
+
+func runPack(cmd *cobra.Command, args []string) error {
+	skillDir := args[0]
+
+	format, err := pack.ParseFormat(packFormat)
+	if err != nil {
+		return err
+	}
+	if err := skill.ValidateSkillDir(skillDir); err != nil {
+		color.Red("❌ %v\n", err)
+		return err
+	}
+
+	output := packOutput
+	if output == "" {
+		absDir, err := filepath.Abs(skillDir)
+		if err != nil {
+			return err
+		}
+		info := skill.LoadSkillInfo(absDir, filepath.Base(absDir))
+		output = fmt.Sprintf("%s.%s", info.Name, format)
+	}
+
+	copyOpts, err := skill.DefaultCopyOptions().WithPatterns(packExclude, packInclude)
+	if err != nil {
+		return err
+	}
+	if copyOpts, err = copyOpts.ForSkill(skillDir); err != nil {
+		return err
+	}
+	if copyOpts.Symlinks, err = skill.ParseSymlinkPolicy(packSymlinks); err != nil {
+		return err
+	}
+	if copyOpts.Symlinks == skill.SymlinkPreserve {
+		return fmt.Errorf("archives cannot contain symlinks, use --symlinks dereference or reject")
+	}
+
+	color.Cyan("📦 Packing: %s\n", skillDir)
+	manifest, err := pack.Pack(skillDir, pack.Options{
+		Format: format,
+		Output: output,
+		Copy:   copyOpts,
+	})
+	if err != nil {
+		color.Red("❌ Pack failed: %v\n", err)
+		return err
+	}
+
+	color.White("   Skill:    %s\n", color.New(color.FgCyan).Sprint(manifest.Name))
+	color.White("   Files:    %d\n", len(manifest.Files))
+	color.White("   SHA-256:  %s\n", manifest.Archive.SHA256)
+	color.Green("\n✅ Archive:  %s\n", output)
+	color.Green("✅ Manifest: %s\n", output+pack.ManifestSuffix)
+
+	if packSignKey != "" {
+		sigPath, method, err := verify.SignArchive(output, config.ExpandHome(packSignKey))
+		if err != nil {
+			color.Red("❌ Sign failed: %v\n", err)
+			return err
+		}
+		color.Green("✅ Signature: %s (%s)\n", sigPath, method)
+	}
+	return nil
+}
@@ -1,17 +1,21 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+
+	"github.com/AlfonsSkills/SkillSync/internal/skill"
+	"github.com/AlfonsSkills/SkillSync/internal/target"
 )
 
 var (
-	localRemove bool
+	localRemove     bool
+	removeProtected bool
 )
 
 // removeCmd remove command
@@ -20,10 +24,15 @@ var removeCmd = &cobra.Command{
 	Short: "Remove installed skill",
 	Long: `Remove an installed skill.
 
+Global copies are looked up in every category of tools that have them (e.g. Codex
+public and .system). Skills in read-only categories such as Codex .system are managed
+by the tool itself and are kept unless --allow-protected is given.
+
 Examples:
   skillsync remove my-skill
   skillsync remove my-skill --target gemini
-  skillsync remove my-skill --local`,
+  skillsync remove my-skill --local
+  skillsync remove my-skill -t codex --allow-protected`,
 	Args: cobra.ExactArgs(1),
 	RunE: runRemove,
 }
@@ -31,6 +40,7 @@ Examples:
 func init() {
 	rootCmd.AddCommand(removeCmd)
 	removeCmd.Flags().BoolVarP(&localRemove, "local", "l", false, "Remove from project-local skills directories only")
+	removeCmd.Flags().BoolVar(&removeProtected, "allow-protected", false, "Allow removing skills in read-only categories such as Codex .system")
 }
 
 func runRemove(cmd *cobra.Command, args []string) error {
@@ -51,7 +61,7 @@ func runRemove(cmd *cobra.Command, args []string) error {
 	}
 
 	// Step 3: Show removal preview
-	showRemovePreview(skillName, providers, removeGlobal, removeLocal, projectRoot)
+	showRemovePreview(skillName, providers, removeGlobal, removeLocal, projectRoot, removeProtected)
 
 	// Step 4: Confirm removal
 	var confirmRemove bool
@@ -74,15 +84,20 @@ func runRemove(cmd *cobra.Command, args []string) error {
 	for _, p := range providers {
 		// Remove from global directory
 		if removeGlobal {
-			globalDir, err := p.GlobalInstallDir()
-			if err == nil {
-				skillPath := filepath.Join(globalDir, skillName)
-				if _, err := os.Stat(skillPath); os.IsNotExist(err) {
-					color.Yellow("   ⚠ %s: not found\n", p.DisplayName())
-				} else if err := os.RemoveAll(skillPath); err != nil {
-					color.Red("   ❌ %s: failed to remove - %v\n", p.DisplayName(), err)
+			results := removeFromProvider(p, skillName, "", removeProtected)
+			if len(results) == 0 {
+				color.Yellow("   ⚠ %s: not found\n", p.DisplayName())
+			}
+			// 同一 skill 存在于多个分类时逐一报告
+			for _, r := range results {
+				name := p.DisplayName()
+				if r.category != "" {
+					name = fmt.Sprintf("%s (%s)", name, r.category)
+				}
+				if r.err != nil {
+					color.Red("   ❌ %s: failed to remove - %v\n", name, r.err)
 				} else {
-					color.Green("   ✓ Removed from %s\n", p.DisplayName())
+					color.Green("   ✓ Removed from %s\n", name)
 					removedCount++
 				}
 			}
@@ -90,15 +105,17 @@ func runRemove(cmd *cobra.Command, args []string) error {
 
 		// Remove from project directory
 		if removeLocal && projectRoot != "" {
-			localDir := p.LocalSkillsDir(projectRoot)
-			skillPath := filepath.Join(localDir, skillName)
-			if _, err := os.Stat(skillPath); os.IsNotExist(err) {
+			results := removeFromProvider(p, skillName, projectRoot, removeProtected)
+			if len(results) == 0 {
 				color.Yellow("   ⚠ .%s/skills: not found\n", p.Type())
-			} else if err := os.RemoveAll(skillPath); err != nil {
-				color.Red("   ❌ .%s/skills: failed to remove - %v\n", p.Type(), err)
-			} else {
-				color.Green("   ✓ Removed from .%s/skills\n", p.Type())
-				removedCount++
+			}
+			for _, r := range results {
+				if r.err != nil {
+					color.Red("   ❌ .%s/skills: failed to remove - %v\n", p.Type(), r.err)
+				} else {
+					color.Green("   ✓ Removed from .%s/skills\n", p.Type())
+					removedCount++
+				}
 			}
 		}
 	}
@@ -111,3 +128,41 @@ func runRemove(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// removeResult 删除单个 skill 副本的结果
+type removeResult struct {
+	category string // 副本所在分类，不在分类子目录中时为空
+	err      error
+}
+
+// removeFromProvider 从工具的全局目录（projectRoot 为空）或项目目录删除 skill
+// 全局目录搜索所有分类并删除每个副本，只读分类中的副本需 --allow-protected 才会删除，其余副本照常删除
+// 自行管理安装的工具（外部插件）由插件删除，不支持时回退为按目录删除
+// 返回: 每个找到的副本的删除结果，未找到时为空
+func removeFromProvider(p target.ToolProvider, skillName, projectRoot string, allowProtected bool) []removeResult {
+	if sm, ok := p.(target.SkillManager); ok {
+		if err := sm.RemoveSkill(skillName, projectRoot); !errors.Is(err, target.ErrUnsupported) {
+			return []removeResult{{err: err}}
+		}
+	}
+
+	if projectRoot != "" {
+		var results []removeResult
+		for _, path := range skill.FindSkillDirs(p.LocalSkillsDir(projectRoot), skillName) {
+			results = append(results, removeResult{err: os.RemoveAll(path)})
+		}
+		return results
+	}
+
+	var results []removeResult
+	for _, c := range findInstalledSkill(p, skillName) {
+		r := removeResult{category: c.category}
+		if target.IsProtectedCategory(p, c.category) && !allowProtected {
+			r.err = fmt.Errorf("category %s is read-only, use --allow-protected to remove it", c.category)
+		} else {
+			r.err = os.RemoveAll(c.path)
+		}
+		results = append(results, r)
+	}
+	return results
+}
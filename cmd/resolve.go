@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/AlfonsSkills/SkillSync/internal/target"
+)
+
+// resolveCmd resolve command
+var resolveCmd = &cobra.Command{
+	Use:   "resolve [skill]",
+	Short: "Show which copy of a skill each tool actually loads",
+	Long: `Show, for each tool, which copy of a skill it will load and which copies are shadowed.
+
+Tools search several directories in order of precedence. Goose, for example, reads
+~/.claude/skills and ~/.config/agents/skills before ~/.config/goose/skills. When the
+same skill exists in more than one of them, the first copy wins. Project directories
+are searched only inside a git repository.
+
+Without a skill name, every skill found in the tools' search paths is resolved.
+
+Examples:
+  skillsync resolve
+  skillsync resolve code-review
+  skillsync resolve code-review --target goose,opencode`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runResolve,
+}
+
+func init() {
+	rootCmd.AddCommand(resolveCmd)
+}
+
+// skillCopy 搜索路径中的一份 skill 副本
+type skillCopy struct {
+	Path  string
+	Scope string // project、shared 或 global
+}
+
+// skillResolution 单个 skill 在某个工具中的解析结果
+// Copies[0] 为实际加载的副本，其余被遮蔽
+type skillResolution struct {
+	Name   string
+	Copies []skillCopy
+}
+
+func runResolve(cmd *cobra.Command, args []string) error {
+	providers, err := target.ParseProviders(targetFlags)
+	if err != nil {
+		return err
+	}
+	// 不在项目中时仅解析全局目录
+	projectRoot, _ := findProjectRoot()
+	providers = target.GroupByDir(providers, projectRoot)
+
+	var skillName string
+	if len(args) > 0 {
+		skillName = args[0]
+	}
+
+	color.Cyan("🔎 Skill resolution:\n")
+	if projectRoot != "" {
+		color.HiCyan("   Project root: %s\n", projectRoot)
+	}
+	fmt.Println()
+
+	found := false
+	for _, p := range providers {
+		var results []skillResolution
+		for _, r := range resolveSkills(p, projectRoot) {
+			if skillName == "" || r.Name == skillName {
+				results = append(results, r)
+			}
+		}
+		if len(results) == 0 {
+			continue
+		}
+		found = true
+
+		color.White("  %s:\n", color.New(color.Bold).Sprint(p.DisplayName()))
+		for _, r := range results {
+			color.White("    %s\n", r.Name)
+			for i, c := range r.Copies {
+				if i == 0 {
+					color.Green("      ✓ %-7s %s\n", c.Scope, c.Path)
+				} else {
+					color.HiBlack("      ✗ %-7s %s (shadowed)\n", c.Scope, c.Path)
+				}
+			}
+		}
+		fmt.Println()
+	}
+
+	if !found {
+		if skillName != "" {
+			return fmt.Errorf("skill '%s' not found in any tool's search paths", skillName)
+		}
+		color.Yellow("📭 No installed skills found\n")
+	}
+	return nil
+}
+
+// resolveSkills 按工具搜索路径的优先级解析各 skill 的副本
+// 同一目录被多个搜索路径引用时只计一次
+func resolveSkills(p target.ToolProvider, projectRoot string) []skillResolution {
+	var results []skillResolution
+	index := make(map[string]int)
+	seenDirs := make(map[string]bool)
+
+	for _, sp := range target.SearchPaths(p, projectRoot) {
+		if sp.Dir == "" || seenDirs[sp.Dir] {
+			continue
+		}
+		seenDirs[sp.Dir] = true
+
+		skills, err := scanSkillsDir(sp.Dir, p)
+		if err != nil {
+			continue
+		}
+		for _, s := range skills {
+			if !s.Valid {
+				continue
+			}
+			c := skillCopy{Path: s.Path, Scope: sp.Scope}
+			if idx, ok := index[s.Name]; ok {
+				results[idx].Copies = append(results[idx].Copies, c)
+				continue
+			}
+			index[s.Name] = len(results)
+			results = append(results, skillResolution{Name: s.Name, Copies: []skillCopy{c}})
+		}
+	}
+	return results
+}
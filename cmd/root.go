@@ -4,10 +4,16 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
-	"github.com/AlfonsSkills/SkillSync/internal/updater"
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+
+	"github.com/AlfonsSkills/SkillSync/internal/config"
+	"github.com/AlfonsSkills/SkillSync/internal/target"
+	"github.com/AlfonsSkills/SkillSync/internal/updater"
 )
 
 // 版本信息（通过 ldflags 注入）
@@ -35,8 +41,9 @@ var rootCmd = &cobra.Command{
 	Long: `SkillSync - Git Skill Sync Tool
 
 Sync skills from Git repositories (default: GitHub) to local AI coding tool directories.
-Supports Gemini CLI, Claude Code, and Codex CLI.
 
+Supported tools:
+%s
 Examples:
   # Install skill to all tools
   skillsync install user/repo
@@ -51,6 +58,10 @@ Examples:
   # Remove skill
   skillsync remove skill-name`,
 	Version: Version,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		registerCustomTargets()
+		registerExternalProviders()
+	},
 }
 
 // Execute 执行根命令
@@ -71,9 +82,103 @@ Author:  %s
 Project: %s
 `, Version, GitCommit, BuildTime, Author, ProjectURL))
 
+	// 工具列表由注册表生成
+	rootCmd.Long = fmt.Sprintf(rootCmd.Long, supportedToolsText())
+
 	// 添加全局 flags
 	rootCmd.PersistentFlags().StringSliceVarP(&targetFlags, "target", "t", []string{},
-		"Target tools (gemini, claude, codex, opencode, goose, crush, antigravity, copilot, cursor, cline, droid, kilocode, roocode, vscode), comma-separated, default: all")
+		fmt.Sprintf("Target tools (%s, or custom targets from config, %s for installed tools, %s for every tool), comma-separated, default: all",
+			strings.Join(toolTypeNames(target.AllToolTypes()), ", "), target.TargetDetected, target.TargetAll))
+	_ = rootCmd.RegisterFlagCompletionFunc("target", completeTargets)
+}
+
+// toolCategoryLabels 工具分类在帮助文本中的名称（有序）
+var toolCategoryLabels = []struct{ category, label string }{
+	{target.CategoryTerminal, "Terminal"},
+	{target.CategoryIDE, "IDE"},
+	{target.CategoryShared, "Shared"},
+}
+
+// supportedToolsText 按分类列出内置工具，用于根命令帮助
+func supportedToolsText() string {
+	var b strings.Builder
+	for _, c := range toolCategoryLabels {
+		var types []target.ToolType
+		for _, t := range target.ToolsInCategory(c.category) {
+			types = append(types, t.Type)
+		}
+		fmt.Fprintf(&b, "  %-9s %s\n", c.label+":", strings.Join(toolTypeNames(types), ", "))
+	}
+	return b.String()
+}
+
+// toolTypeNames 返回工具类型的字符串形式
+func toolTypeNames(types []target.ToolType) []string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.String()
+	}
+	return names
+}
+
+// completeTargets 补全 --target 的取值，支持逗号分隔的多个工具
+func completeTargets(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix = toComplete[:i+1]
+	}
+	chosen := strings.Split(prefix, ",")
+
+	var completions []string
+	for _, t := range target.Tools() {
+		if !slices.Contains(chosen, t.Type.String()) {
+			completions = append(completions, prefix+t.Type.String()+"\t"+t.Description)
+		}
+	}
+	if !slices.Contains(chosen, target.TargetDetected) {
+		completions = append(completions, prefix+target.TargetDetected+"\tAll installed tools")
+	}
+	if !slices.Contains(chosen, target.TargetAll) {
+		completions = append(completions, prefix+target.TargetAll+"\tEvery supported tool")
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// registerCustomTargets 注册配置文件中声明的自定义目标工具
+// 配置无效时仅警告，不影响其他命令
+func registerCustomTargets() {
+	cfg, err := config.Load()
+	if err != nil {
+		color.Yellow("⚠ Ignoring custom targets: %v\n", err)
+		return
+	}
+	for _, t := range cfg.Targets {
+		p, err := target.NewCustomProvider(target.CustomSpec{
+			ID:          t.ID,
+			DisplayName: t.DisplayName,
+			GlobalDir:   t.GlobalDir,
+			InstallDir:  t.InstallDir,
+			LocalDir:    t.LocalDir,
+			Categories:  t.Categories,
+		})
+		if err == nil {
+			err = target.Register(p)
+		}
+		if err != nil {
+			color.Yellow("⚠ Ignoring custom target in %s: %v\n", cfg.Path, err)
+		}
+	}
+}
+
+// registerExternalProviders 注册 PATH 中的 skillsync-provider-<name> 插件
+// 与内置或配置文件中的工具同名时忽略插件
+func registerExternalProviders() {
+	paths, order := target.DiscoverExternalProviders()
+	for _, id := range order {
+		if err := target.Register(target.NewExternalProvider(id, paths[id])); err != nil {
+			color.Yellow("⚠ Ignoring provider plugin %s: %v\n", paths[id], err)
+		}
+	}
 }
 
 // checkUpdateInBackground 检查更新（带超时）
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/AlfonsSkills/SkillSync/internal/config"
+	"github.com/AlfonsSkills/SkillSync/internal/git"
+	"github.com/AlfonsSkills/SkillSync/internal/pack"
+	"github.com/AlfonsSkills/SkillSync/internal/skill"
+	"github.com/AlfonsSkills/SkillSync/internal/verify"
+)
+
+var (
+	verifyPolicyFlag string
+	verifySigFlag    string
+)
+
+// verifyCmd verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify <archive>",
+	Short: "Verify the detached signature of a packed skill archive",
+	Long: `Verify a skill archive created by 'skillsync pack --sign-key'.
+
+SSH signatures are checked against verify.allowed-signers and ed25519 signatures
+against verify.public-keys in ~/.config/skillsync/config.yaml:
+
+  verify:
+    allowed-signers: ~/.config/skillsync/allowed_signers
+    public-keys: [~/.config/skillsync/release.pub]
+    policy: warn                # off | warn | require
+    sources:
+      github.com/acme/*: require
+
+Examples:
+  skillsync verify my-skill.tar.gz
+  skillsync verify my-skill.zip --sig my-skill.zip.sig`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVerify,
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+	verifyCmd.Flags().StringVar(&verifySigFlag, "sig", "", "Signature file (default: <archive>.sig)")
+	installCmd.Flags().StringVar(&verifyPolicyFlag, "verify", "", "Signature verification policy for this install (off, warn, require), overrides config")
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	archive := args[0]
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	sigPath := verifySigFlag
+	if sigPath == "" {
+		sigPath = archive + verify.SignatureSuffix
+	}
+
+	color.Cyan("🔏 Verifying: %s\n", archive)
+	manifest, err := pack.CheckManifest(archive)
+	if err != nil {
+		color.Red("❌ %v\n", err)
+		return err
+	}
+	if manifest != nil {
+		color.White("   Manifest: SHA-256 matches (%d files)\n", len(manifest.Files))
+	}
+
+	result := verify.VerifyArchive(archive, sigPath, cfg.Verify.AllowedSigners, cfg.Verify.PublicKeys)
+	if !result.Verified() {
+		color.Red("❌ Signature %s: %s\n", result.Status, result.Detail)
+		return fmt.Errorf("signature verification failed")
+	}
+	color.Green("✅ Signature verified (%s): %s\n", result.Method, result.Signer)
+	return nil
+}
+
+// sourceCheck 记录单个来源的校验策略与结果
+type sourceCheck struct {
+	source string
+	ref    string
+	policy config.Policy
+	result verify.Result
+}
+
+// sourceVerifier 按配置策略校验安装来源，并记录结果用于写入 provenance
+type sourceVerifier struct {
+	cfg      config.VerifyConfig
+	override config.Policy // --verify 指定的策略，为空时使用配置
+	fetcher  *git.Fetcher
+	checks   map[string]sourceCheck // key: 本地目录
+}
+
+// newSourceVerifier 加载配置并创建校验器
+func newSourceVerifier(fetcher *git.Fetcher, policyFlag string) (*sourceVerifier, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	var override config.Policy
+	if policyFlag != "" {
+		if override, err = config.ParsePolicy(policyFlag); err != nil {
+			return nil, err
+		}
+	}
+	return &sourceVerifier{
+		cfg:      cfg.Verify,
+		override: override,
+		fetcher:  fetcher,
+		checks:   make(map[string]sourceCheck),
+	}, nil
+}
+
+// policyFor 返回来源适用的策略
+func (v *sourceVerifier) policyFor(source string) config.Policy {
+	if v.override != "" {
+		return v.override
+	}
+	keys := []string{source}
+	if key, err := v.fetcher.RepoKey(source); err == nil {
+		keys = append(keys, key)
+	}
+	return v.cfg.PolicyFor(keys...)
+}
+
+// checkGit 校验 git 来源的签名提交或标签
+func (v *sourceVerifier) checkGit(source, ref, dir string) error {
+	policy := v.policyFor(source)
+	result := verify.Result{Status: verify.StatusSkipped, Commit: verify.GitCommit(dir)}
+	if policy != config.PolicyOff {
+		result = verify.VerifyGit(dir, v.cfg.AllowedSigners)
+	}
+	// 关键步骤：allowed-signers 固定 SSH 签名者，配置后 VerifyGit 拒绝其他格式的签名
+	// 未配置时 gpg 密钥环中的任意密钥都能通过校验，require 策略下视为校验失败，warn 策略下给出警告
+	if result.Verified() && v.cfg.AllowedSigners == "" {
+		if policy == config.PolicyRequire {
+			result.Status = verify.StatusInvalid
+			result.Detail = fmt.Sprintf("signed by %s, but policy 'require' needs verify.allowed-signers to pin trusted signers", result.Signer)
+		} else {
+			color.Yellow("⚠ No verify.allowed-signers configured: any key in your gpg keyring is trusted\n")
+		}
+	}
+	return v.record(source, ref, dir, policy, result)
+}
+
+// checkArchive 校验归档来源的分离签名
+func (v *sourceVerifier) checkArchive(archive, dir string) error {
+	policy := v.policyFor(archive)
+	result := verify.Result{Status: verify.StatusSkipped}
+	if policy != config.PolicyOff {
+		result = verify.VerifyArchive(archive, archive+verify.SignatureSuffix, v.cfg.AllowedSigners, v.cfg.PublicKeys)
+	}
+	return v.record(archive, "", dir, policy, result)
+}
+
+// record 输出校验结果并按策略决定是否终止
+func (v *sourceVerifier) record(source, ref, dir string, policy config.Policy, result verify.Result) error {
+	v.checks[dir] = sourceCheck{source: source, ref: ref, policy: policy, result: result}
+
+	switch {
+	case result.Status == verify.StatusSkipped:
+		return nil
+	case result.Verified():
+		color.Green("🔏 Signature verified (%s): %s\n\n", result.Method, result.Signer)
+		return nil
+	case policy == config.PolicyRequire:
+		color.Red("❌ Signature %s for %s: %s\n", result.Status, source, result.Detail)
+		color.Yellow("   Verify policy is 'require', refusing to install.\n")
+		return fmt.Errorf("signature verification failed for %s", source)
+	default:
+		color.Yellow("⚠ Signature %s for %s: %s (policy: warn, continuing)\n\n", result.Status, source, result.Detail)
+		return nil
+	}
+}
+
+// provenanceFor 构建 skill 的来源记录
+// 通过 skill 路径找到其所在的来源目录
+func (v *sourceVerifier) provenanceFor(s skill.SkillInfo) skill.Provenance {
+	p := skill.Provenance{Name: s.Name, InstalledAt: time.Now().UTC()}
+	for dir, check := range v.checks {
+		rel, err := filepath.Rel(dir, s.Path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		p.Source = check.source
+		p.Ref = check.ref
+		p.Commit = check.result.Commit
+		p.VerifyPolicy = string(check.policy)
+		p.Verification = check.result
+		break
+	}
+	return p
+}
+
+// extractArchiveSource 将归档来源解压到临时目录
+func extractArchiveSource(archive string) (string, error) {
+	tempDir, err := os.MkdirTemp("", "skillsync-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	if err := pack.Extract(archive, tempDir); err != nil {
+		os.RemoveAll(tempDir)
+		return "", err
+	}
+	return tempDir, nil
+}
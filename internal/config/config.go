@@ -0,0 +1,195 @@
+// Package config 加载 SkillSync 用户配置文件
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/AlfonsSkills/SkillSync/internal/yaml"
+)
+
+// FileName 配置文件名
+const FileName = "config.yaml"
+
+// Policy 表示来源签名校验策略
+type Policy string
+
+const (
+	PolicyOff     Policy = "off"     // 不校验
+	PolicyWarn    Policy = "warn"    // 校验失败时警告并继续
+	PolicyRequire Policy = "require" // 校验失败时终止安装
+)
+
+// ParsePolicy 解析策略字符串
+func ParsePolicy(s string) (Policy, error) {
+	switch Policy(strings.ToLower(strings.TrimSpace(s))) {
+	case PolicyOff, "":
+		return PolicyOff, nil
+	case PolicyWarn:
+		return PolicyWarn, nil
+	case PolicyRequire:
+		return PolicyRequire, nil
+	}
+	return "", fmt.Errorf("invalid verify policy: %s, valid policies are: off, warn, require", s)
+}
+
+// SourcePolicy 针对特定来源的校验策略
+type SourcePolicy struct {
+	Pattern string // 仓库 key（host/owner/repo）或 glob，如 github.com/acme/*
+	Policy  Policy
+}
+
+// VerifyConfig 签名校验配置
+//
+//	verify:
+//	  allowed-signers: ~/.config/skillsync/allowed_signers
+//	  public-keys: [~/.config/skillsync/release.pub]   # ed25519 公钥（PEM）
+//	  policy: warn                                     # 默认策略
+//	  sources:
+//	    github.com/acme/*: require
+type VerifyConfig struct {
+	AllowedSigners string         // SSH allowed signers 文件（git 与归档 SSH 签名共用）
+	PublicKeys     []string       // ed25519 公钥文件（归档签名）
+	Policy         Policy         // 默认策略
+	Sources        []SourcePolicy // 按来源覆盖的策略
+}
+
+// CustomTarget 用户声明的自定义目标工具
+//
+//	targets:
+//	  - id: acme
+//	    name: Acme Agent
+//	    global-dir: ~/.acme-agent/skills
+//	    install-dir: ~/.acme-agent/skills   # 可选，默认与 global-dir 相同
+//	    local-dir: .acme/skills             # 相对项目根目录，支持 {project} 占位符
+//	    categories: [public]                # 可选
+type CustomTarget struct {
+	ID          string
+	DisplayName string
+	GlobalDir   string
+	InstallDir  string
+	LocalDir    string
+	Categories  []string
+}
+
+// Config 表示用户配置
+type Config struct {
+	Path    string // 配置文件路径（不存在时也会填充）
+	Verify  VerifyConfig
+	Targets []CustomTarget
+}
+
+// Dir 返回 SkillSync 配置目录
+// 优先 $XDG_CONFIG_HOME/skillsync，其次 ~/.config/skillsync
+func Dir() (string, error) {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "skillsync"), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".config", "skillsync"), nil
+}
+
+// Load 加载用户配置，文件不存在时返回默认配置
+func Load() (*Config, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFile(filepath.Join(dir, FileName))
+}
+
+// LoadFile 加载指定路径的配置文件
+func LoadFile(file string) (*Config, error) {
+	cfg := &Config{Path: file, Verify: VerifyConfig{Policy: PolicyOff}}
+
+	content, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	raw, err := yaml.ParseMap(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", file, err)
+	}
+
+	if v := yaml.Map(raw, "verify"); v != nil {
+		cfg.Verify.AllowedSigners = ExpandHome(yaml.String(v, "allowed-signers"))
+		for _, key := range yaml.StringSlice(v["public-keys"]) {
+			cfg.Verify.PublicKeys = append(cfg.Verify.PublicKeys, ExpandHome(key))
+		}
+		if cfg.Verify.Policy, err = ParsePolicy(yaml.String(v, "policy")); err != nil {
+			return nil, err
+		}
+		for pattern, value := range yaml.Map(v, "sources") {
+			s, _ := value.(string)
+			policy, err := ParsePolicy(s)
+			if err != nil {
+				return nil, fmt.Errorf("verify.sources[%s]: %w", pattern, err)
+			}
+			cfg.Verify.Sources = append(cfg.Verify.Sources, SourcePolicy{Pattern: strings.ToLower(pattern), Policy: policy})
+		}
+		// 关键步骤：更长（更具体）的模式优先匹配，长度相同时按模式排序，保证结果确定
+		sort.SliceStable(cfg.Verify.Sources, func(i, j int) bool {
+			a, b := cfg.Verify.Sources[i].Pattern, cfg.Verify.Sources[j].Pattern
+			if len(a) != len(b) {
+				return len(a) > len(b)
+			}
+			return a < b
+		})
+	}
+
+	items, _ := raw["targets"].([]any)
+	for i, item := range items {
+		t, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid config %s: targets[%d] must be a mapping", file, i)
+		}
+		cfg.Targets = append(cfg.Targets, CustomTarget{
+			ID:          yaml.String(t, "id"),
+			DisplayName: yaml.String(t, "name"),
+			GlobalDir:   yaml.String(t, "global-dir"),
+			InstallDir:  yaml.String(t, "install-dir"),
+			LocalDir:    yaml.String(t, "local-dir"),
+			Categories:  yaml.StringSlice(t["categories"]),
+		})
+	}
+
+	return cfg, nil
+}
+
+// PolicyFor 返回指定来源适用的校验策略
+// 入参: keys 来源的候选标识（如仓库 key、原始输入），任一匹配即生效
+func (v VerifyConfig) PolicyFor(keys ...string) Policy {
+	for _, sp := range v.Sources {
+		for _, key := range keys {
+			key = strings.ToLower(key)
+			if key == sp.Pattern {
+				return sp.Policy
+			}
+			if ok, _ := path.Match(sp.Pattern, key); ok {
+				return sp.Policy
+			}
+		}
+	}
+	return v.Policy
+}
+
+// ExpandHome 展开路径开头的 ~/
+func ExpandHome(p string) string {
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(homeDir, strings.TrimPrefix(p, "~"))
+		}
+	}
+	return p
+}
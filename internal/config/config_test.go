@@ -0,0 +1,101 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeConfig 写入临时配置文件并加载
+func writeConfig(t *testing.T, content string) *Config {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadFile(file)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	return cfg
+}
+
+func TestLoadFileMissing(t *testing.T) {
+	cfg, err := LoadFile(filepath.Join(t.TempDir(), FileName))
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if cfg.Verify.Policy != PolicyOff {
+		t.Errorf("default policy = %s, want off", cfg.Verify.Policy)
+	}
+}
+
+func TestPolicyFor(t *testing.T) {
+	cfg := writeConfig(t, `verify:
+  policy: warn
+  sources:
+    github.com/acme/*: require
+    github.com/acme/tools: off
+    github.com/*/tools: warn
+    github.com/beta/*: off
+    GitHub.com/Gamma/*: require
+`)
+
+	tests := []struct {
+		key  string
+		want Policy
+	}{
+		{"github.com/acme/skills", PolicyRequire},
+		{"github.com/acme/tools", PolicyOff},
+		{"github.com/other/tools", PolicyWarn},
+		{"github.com/gamma/x", PolicyRequire},
+		{"gitlab.com/acme/skills", PolicyWarn},
+		{"github.com/beta/x", PolicyOff},
+	}
+	for _, tt := range tests {
+		if got := cfg.Verify.PolicyFor(tt.key); got != tt.want {
+			t.Errorf("PolicyFor(%q) = %s, want %s", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestPolicyTieBreakIsDeterministic(t *testing.T) {
+	// 两个等长模式同时匹配时，结果不随 map 遍历顺序变化
+	for i := 0; i < 20; i++ {
+		cfg := writeConfig(t, `verify:
+  sources:
+    github.com/*/b: require
+    github.com/a/*: off
+`)
+		if got := cfg.Verify.PolicyFor("github.com/a/b"); got != PolicyRequire {
+			t.Fatalf("PolicyFor() = %s, want require from github.com/*/b", got)
+		}
+	}
+}
+
+func TestLoadFileInvalidPolicy(t *testing.T) {
+	file := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(file, []byte("verify:\n  policy: maybe\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFile(file); err == nil {
+		t.Error("LoadFile() error = nil, want invalid policy error")
+	}
+}
+
+func TestLoadFileTargets(t *testing.T) {
+	cfg := writeConfig(t, `targets:
+  - id: acme
+    name: Acme Agent
+    global-dir: ~/.acme/skills
+    local-dir: .acme/skills
+    categories: [public]
+`)
+	if len(cfg.Targets) != 1 {
+		t.Fatalf("targets = %d, want 1", len(cfg.Targets))
+	}
+	got := cfg.Targets[0]
+	if got.ID != "acme" || got.DisplayName != "Acme Agent" || got.GlobalDir != "~/.acme/skills" || len(got.Categories) != 1 {
+		t.Errorf("target = %+v", got)
+	}
+}
@@ -0,0 +1,143 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/AlfonsSkills/SkillSync/internal/skill"
+	"github.com/AlfonsSkills/SkillSync/internal/yaml"
+)
+
+// pluginsDir 插件目录在市场根目录下的位置
+const pluginsDir = "plugins"
+
+// ClaudePluginOptions Claude Code 插件市场导出选项
+type ClaudePluginOptions struct {
+	Name         string            // 市场名称
+	Owner        string            // 维护者名称
+	DefaultOwner string            // 未指定且已有清单中也没有维护者时使用
+	Bundle       string            // 非空时所有 skill 合并为该名称的单个插件，否则每个 skill 一个插件
+	Copy         skill.CopyOptions // 拷贝选项（会按 skill 合并 .skillsyncignore）
+}
+
+// ExportClaudePlugin 将 skill 导出为 Claude Code 插件市场
+// 目录结构：
+//
+//	<outDir>/.claude-plugin/marketplace.json
+//	<outDir>/plugins/<plugin>/.claude-plugin/plugin.json
+//	<outDir>/plugins/<plugin>/skills/<skill>/SKILL.md
+//
+// 已有 marketplace.json 时保留其中其他插件条目，同名插件被替换
+func ExportClaudePlugin(skills []skill.SkillInfo, outDir string, opts ClaudePluginOptions) (*skill.Marketplace, error) {
+	market, err := skill.ReadMarketplace(outDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		market = &skill.Marketplace{}
+	}
+	if opts.Name != "" {
+		market.Name = opts.Name
+	}
+	if market.Name == "" {
+		market.Name = filepath.Base(outDir)
+	}
+	if opts.Owner != "" {
+		market.Owner.Name = opts.Owner
+	}
+	if market.Owner.Name == "" {
+		market.Owner.Name = opts.DefaultOwner
+	}
+
+	// 关键步骤：按插件分组
+	groups := make(map[string][]skill.SkillInfo)
+	var order []string
+	for _, s := range skills {
+		name := s.Name
+		if opts.Bundle != "" {
+			name = opts.Bundle
+		}
+		if _, ok := groups[name]; !ok {
+			order = append(order, name)
+		}
+		groups[name] = append(groups[name], s)
+	}
+
+	for _, name := range order {
+		entry, err := writePlugin(outDir, name, groups[name], market.Owner, opts)
+		if err != nil {
+			return nil, err
+		}
+		replacePlugin(market, entry)
+	}
+
+	if err := skill.WriteMarketplace(outDir, market); err != nil {
+		return nil, fmt.Errorf("failed to write marketplace: %w", err)
+	}
+	return market, nil
+}
+
+// writePlugin 生成单个插件目录并返回市场条目
+func writePlugin(outDir, name string, skills []skill.SkillInfo, owner skill.MarketplaceOwner, opts ClaudePluginOptions) (skill.MarketplacePlugin, error) {
+	pluginDir := filepath.Join(outDir, pluginsDir, name)
+	if err := os.RemoveAll(pluginDir); err != nil {
+		return skill.MarketplacePlugin{}, err
+	}
+
+	manifest := &skill.PluginManifest{Name: name}
+	if owner.Name != "" {
+		manifest.Author = &owner
+	}
+	if len(skills) == 1 {
+		manifest.Description = skills[0].Desc
+		manifest.Version = skillVersion(skills[0].Path)
+	} else {
+		manifest.Description = fmt.Sprintf("%d skills exported by skillsync", len(skills))
+	}
+
+	for _, s := range skills {
+		copyOpts, err := opts.Copy.ForSkill(s.Path)
+		if err != nil {
+			return skill.MarketplacePlugin{}, err
+		}
+		dest := filepath.Join(pluginDir, skill.PluginSkillsDir, s.Name)
+		if err := skill.CopyDir(s.Path, dest, copyOpts); err != nil {
+			return skill.MarketplacePlugin{}, fmt.Errorf("failed to copy %s: %w", s.Name, err)
+		}
+	}
+	if err := skill.WritePluginManifest(pluginDir, manifest); err != nil {
+		return skill.MarketplacePlugin{}, fmt.Errorf("failed to write plugin manifest: %w", err)
+	}
+
+	return skill.MarketplacePlugin{
+		Name:        name,
+		Source:      "./" + path.Join(pluginsDir, name),
+		Description: manifest.Description,
+		Version:     manifest.Version,
+	}, nil
+}
+
+// replacePlugin 替换或追加市场中的插件条目
+func replacePlugin(market *skill.Marketplace, entry skill.MarketplacePlugin) {
+	for i, p := range market.Plugins {
+		if p.Name == entry.Name {
+			market.Plugins[i] = entry
+			return
+		}
+	}
+	market.Plugins = append(market.Plugins, entry)
+}
+
+// skillVersion 读取 frontmatter 中的 version（顶层或 metadata 下）
+func skillVersion(skillDir string) string {
+	meta, err := skill.ReadMetadata(skillDir)
+	if err != nil {
+		return ""
+	}
+	if v := yaml.String(meta.Raw, "version"); v != "" {
+		return v
+	}
+	return yaml.String(yaml.Map(meta.Raw, "metadata"), "version")
+}
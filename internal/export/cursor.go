@@ -0,0 +1,210 @@
+// Package export 将 skill 转换为其他工具的原生格式
+package export
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/AlfonsSkills/SkillSync/internal/skill"
+	"github.com/AlfonsSkills/SkillSync/internal/yaml"
+)
+
+// CursorRuleExt Cursor 规则文件扩展名
+const CursorRuleExt = ".mdc"
+
+// maxInlineSize 引用的文本文件不超过该大小时直接内联到规则中
+const maxInlineSize = 32 * 1024
+
+// inlineExts 可内联的文本文件扩展名
+var inlineExts = map[string]bool{".md": true, ".markdown": true, ".txt": true}
+
+// markdownLink 匹配 Markdown 链接目标
+var markdownLink = regexp.MustCompile(`\]\(([^)\s]+)\)`)
+
+// CursorRule 表示一个 Cursor .mdc 规则
+type CursorRule struct {
+	Description string
+	Globs       []string
+	AlwaysApply bool
+	Body        string
+}
+
+// Render 生成 .mdc 文件内容
+func (r CursorRule) Render() []byte {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "description: %s\n", strings.Join(strings.Fields(r.Description), " "))
+	fmt.Fprintf(&b, "globs: %s\n", strings.Join(r.Globs, ","))
+	fmt.Fprintf(&b, "alwaysApply: %t\n", r.AlwaysApply)
+	b.WriteString("---\n\n")
+	b.WriteString(strings.TrimLeft(r.Body, "\n"))
+	if !strings.HasSuffix(r.Body, "\n") {
+		b.WriteString("\n")
+	}
+	return []byte(b.String())
+}
+
+// cursorOptions 从元数据读取 globs / alwaysApply
+// 查找顺序：metadata.cursor，其次 metadata，最后顶层字段
+func cursorOptions(meta *skill.Metadata) ([]string, bool) {
+	metadata := yaml.Map(meta.Raw, "metadata")
+	for _, m := range []map[string]any{yaml.Map(metadata, "cursor"), metadata, meta.Raw} {
+		if m == nil {
+			continue
+		}
+		globs := yaml.StringSlice(m["globs"])
+		always, hasAlways := m["alwaysApply"]
+		if !hasAlways {
+			always, hasAlways = m["always-apply"]
+		}
+		if len(globs) > 0 || hasAlways {
+			return globs, yaml.Bool(always)
+		}
+	}
+	return nil, false
+}
+
+// ExportCursorRule 将 skill 导出为 Cursor 规则 <rulesDir>/<name>.mdc
+// description 映射为规则描述，globs / alwaysApply 来自 frontmatter；
+// 引用的小型文本文件内联到规则末尾，其余支持文件拷贝到 <rulesDir>/<name>/ 并改写链接
+// skill 先按 opts 暂存（排除规则、符号链接策略与目录边界检查与安装一致），内联与拷贝都基于暂存副本
+// 返回: 写入的规则文件路径
+func ExportCursorRule(s skill.SkillInfo, rulesDir string, opts skill.CopyOptions) (string, error) {
+	stage, err := os.MkdirTemp("", "skillsync-export-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(stage)
+	staged := filepath.Join(stage, "skill")
+	if err := skill.CopyDir(s.Path, staged, opts); err != nil {
+		return "", err
+	}
+
+	content, err := os.ReadFile(filepath.Join(staged, "SKILL.md"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read SKILL.md: %w", err)
+	}
+	meta := skill.ParseMetadata(content)
+	_, body, _ := skill.SplitFrontmatter(content)
+
+	rule := CursorRule{Description: meta.Description}
+	rule.Globs, rule.AlwaysApply = cursorOptions(meta)
+
+	// 关键步骤：处理正文中的相对链接
+	inlined := make(map[string]bool)
+	var inlineSections []string
+	needsSupport := false
+	text := markdownLink.ReplaceAllStringFunc(string(body), func(m string) string {
+		target := markdownLink.FindStringSubmatch(m)[1]
+		rel, ok := localReference(staged, target)
+		if !ok {
+			return m
+		}
+		if section, ok := inlineReference(staged, rel); ok {
+			if !inlined[rel] {
+				inlined[rel] = true
+				inlineSections = append(inlineSections, section)
+			}
+			return m
+		}
+		needsSupport = true
+		return "](" + path.Join(s.Name, rel) + ")"
+	})
+
+	// 支持文件不含 SKILL.md 与已内联的文件
+	removeStaged(staged, "SKILL.md")
+	for rel := range inlined {
+		removeStaged(staged, rel)
+	}
+
+	supportDir := filepath.Join(rulesDir, s.Name)
+	if err := os.RemoveAll(supportDir); err != nil {
+		return "", err
+	}
+	if needsSupport || hasSupportFiles(staged) {
+		if err := skill.CopyDir(staged, supportDir, skill.CopyOptions{Symlinks: opts.Symlinks}); err != nil {
+			return "", err
+		}
+		text = fmt.Sprintf("Supporting files for this rule are in `%s/` next to this file.\n\n", s.Name) + strings.TrimLeft(text, "\n")
+	}
+
+	for _, section := range inlineSections {
+		text = strings.TrimRight(text, "\n") + "\n\n" + section
+	}
+	rule.Body = text
+
+	if err := os.MkdirAll(rulesDir, 0755); err != nil {
+		return "", err
+	}
+	rulePath := filepath.Join(rulesDir, s.Name+CursorRuleExt)
+	if err := os.WriteFile(rulePath, rule.Render(), 0644); err != nil {
+		return "", fmt.Errorf("failed to write rule: %w", err)
+	}
+	return rulePath, nil
+}
+
+// localReference 判断链接是否指向暂存 skill 目录内的普通文件
+// 使用 Lstat，preserve 策略保留的符号链接不视为可内联的文件
+// 返回: 相对 skill 目录的路径
+func localReference(skillDir, target string) (string, bool) {
+	if strings.Contains(target, "://") || strings.HasPrefix(target, "#") || strings.HasPrefix(target, "mailto:") || path.IsAbs(target) {
+		return "", false
+	}
+	target, _, _ = strings.Cut(target, "#")
+	rel := path.Clean(target)
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, "../") || rel == "SKILL.md" {
+		return "", false
+	}
+	info, err := os.Lstat(filepath.Join(skillDir, filepath.FromSlash(rel)))
+	if err != nil || info.IsDir() {
+		return "", false
+	}
+	return rel, true
+}
+
+// inlineReference 读取可内联的引用文件，生成附加章节
+func inlineReference(skillDir, rel string) (string, bool) {
+	if !inlineExts[strings.ToLower(path.Ext(rel))] {
+		return "", false
+	}
+	p := filepath.Join(skillDir, filepath.FromSlash(rel))
+	info, err := os.Lstat(p)
+	if err != nil || !info.Mode().IsRegular() || info.Size() > maxInlineSize {
+		return "", false
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return "", false
+	}
+	return fmt.Sprintf("## %s\n\n%s\n", rel, strings.TrimSpace(string(data))), true
+}
+
+// removeStaged 删除暂存目录中的文件，并清理因此变空的上级目录
+func removeStaged(stagedDir, rel string) {
+	p := filepath.Join(stagedDir, filepath.FromSlash(rel))
+	if os.Remove(p) != nil {
+		return
+	}
+	for dir := filepath.Dir(p); dir != stagedDir && strings.HasPrefix(dir, stagedDir); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			return
+		}
+	}
+}
+
+// hasSupportFiles 判断暂存目录中是否还有需要安装的文件
+func hasSupportFiles(stagedDir string) bool {
+	found := false
+	filepath.WalkDir(stagedDir, func(p string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		found = true
+		return filepath.SkipAll
+	})
+	return found
+}
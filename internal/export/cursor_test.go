@@ -0,0 +1,135 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AlfonsSkills/SkillSync/internal/skill"
+)
+
+// writeFile 写入测试文件并创建父目录
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// exists 判断路径是否存在（不跟随符号链接）
+func exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+const skillMD = `---
+name: demo
+description: Demo skill
+metadata:
+  cursor:
+    globs: ["*.go", "*.md"]
+---
+
+Read [the notes](references/notes.md) and run [the script](scripts/run.sh).
+See [docs](https://example.com) too.
+`
+
+func TestExportCursorRule(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "demo")
+	writeFile(t, filepath.Join(dir, "SKILL.md"), skillMD)
+	writeFile(t, filepath.Join(dir, "references", "notes.md"), "Inline me.\n")
+	writeFile(t, filepath.Join(dir, "scripts", "run.sh"), "echo hi\n")
+	rulesDir := filepath.Join(t.TempDir(), "rules")
+
+	rulePath, err := ExportCursorRule(skill.SkillInfo{Name: "demo", Path: dir}, rulesDir, skill.DefaultCopyOptions())
+	if err != nil {
+		t.Fatalf("ExportCursorRule() error = %v", err)
+	}
+	data, err := os.ReadFile(rulePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := string(data)
+	for _, want := range []string{
+		"description: Demo skill\n",
+		"globs: *.go,*.md\n",
+		"alwaysApply: false\n",
+		"](demo/scripts/run.sh)",
+		"](https://example.com)",
+		"## references/notes.md\n\nInline me.",
+	} {
+		if !strings.Contains(rule, want) {
+			t.Errorf("rule does not contain %q:\n%s", want, rule)
+		}
+	}
+
+	support := filepath.Join(rulesDir, "demo")
+	if !exists(filepath.Join(support, "scripts", "run.sh")) {
+		t.Error("support script not copied")
+	}
+	for _, rel := range []string{"SKILL.md", "references/notes.md", "references"} {
+		if exists(filepath.Join(support, filepath.FromSlash(rel))) {
+			t.Errorf("%s copied to support directory", rel)
+		}
+	}
+}
+
+func TestExportCursorRuleInlineOnly(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "demo")
+	writeFile(t, filepath.Join(dir, "SKILL.md"), "---\nname: demo\ndescription: d\n---\n\nSee [notes](notes.md).\n")
+	writeFile(t, filepath.Join(dir, "notes.md"), "Inline me.\n")
+	rulesDir := t.TempDir()
+
+	if _, err := ExportCursorRule(skill.SkillInfo{Name: "demo", Path: dir}, rulesDir, skill.DefaultCopyOptions()); err != nil {
+		t.Fatalf("ExportCursorRule() error = %v", err)
+	}
+	if exists(filepath.Join(rulesDir, "demo")) {
+		t.Error("support directory created although every referenced file was inlined")
+	}
+}
+
+func TestExportCursorRuleSymlinks(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "demo")
+	writeFile(t, filepath.Join(dir, "SKILL.md"), "---\nname: demo\ndescription: d\n---\n\nSee [notes](notes.md).\n")
+	writeFile(t, filepath.Join(root, "credentials"), "SECRET\n")
+	if err := os.Symlink(filepath.Join("..", "credentials"), filepath.Join(dir, "notes.md")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	rulesDir := t.TempDir()
+
+	// 指向 skill 目录之外的链接与安装一样被拒绝
+	if _, err := ExportCursorRule(skill.SkillInfo{Name: "demo", Path: dir}, rulesDir, skill.DefaultCopyOptions()); err == nil {
+		t.Fatal("ExportCursorRule() error = nil, want error for link outside the skill")
+	}
+	if data, _ := os.ReadFile(filepath.Join(rulesDir, "demo"+CursorRuleExt)); strings.Contains(string(data), "SECRET") {
+		t.Error("linked file outside the skill was inlined")
+	}
+
+	// reject 策略拒绝任何链接
+	os.Remove(filepath.Join(dir, "notes.md"))
+	writeFile(t, filepath.Join(dir, "real.md"), "Real.\n")
+	if err := os.Symlink("real.md", filepath.Join(dir, "notes.md")); err != nil {
+		t.Fatal(err)
+	}
+	opts := skill.DefaultCopyOptions()
+	opts.Symlinks = skill.SymlinkReject
+	if _, err := ExportCursorRule(skill.SkillInfo{Name: "demo", Path: dir}, rulesDir, opts); err == nil {
+		t.Error("ExportCursorRule() error = nil, want error under reject policy")
+	}
+
+	// preserve 策略保留的链接不内联
+	opts.Symlinks = skill.SymlinkPreserve
+	rulePath, err := ExportCursorRule(skill.SkillInfo{Name: "demo", Path: dir}, rulesDir, opts)
+	if err != nil {
+		t.Fatalf("ExportCursorRule() error = %v", err)
+	}
+	data, _ := os.ReadFile(rulePath)
+	if strings.Contains(string(data), "## notes.md") {
+		t.Error("preserved symlink was inlined")
+	}
+}
@@ -0,0 +1,90 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// 受管区块分隔标记，区块外的内容由用户维护，不会被修改
+const (
+	IndexBeginMarker = "<!-- skillsync:begin -->"
+	IndexEndMarker   = "<!-- skillsync:end -->"
+)
+
+// IndexEntry 索引中的单个 skill
+type IndexEntry struct {
+	Name        string
+	Description string
+	Paths       []string // SKILL.md 相对项目根目录的路径
+}
+
+// RenderSkillIndex 生成受管区块内容（包含起止标记）
+func RenderSkillIndex(entries []IndexEntry) string {
+	sorted := append([]IndexEntry{}, entries...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var b strings.Builder
+	b.WriteString(IndexBeginMarker + "\n")
+	b.WriteString("<!-- Generated by `skillsync index`. Edits inside this section are overwritten. -->\n")
+	b.WriteString("## Project Skills\n\n")
+	if len(sorted) == 0 {
+		b.WriteString("No project skills are installed.\n")
+	} else {
+		b.WriteString("The following skills are available in this project. When a task matches a skill's description, read its `SKILL.md` and follow the instructions.\n\n")
+		for _, e := range sorted {
+			desc := strings.Join(strings.Fields(e.Description), " ")
+			if desc != "" {
+				fmt.Fprintf(&b, "- **%s**: %s\n", e.Name, desc)
+			} else {
+				fmt.Fprintf(&b, "- **%s**\n", e.Name)
+			}
+			for _, p := range e.Paths {
+				fmt.Fprintf(&b, "  - `%s`\n", p)
+			}
+		}
+	}
+	b.WriteString(IndexEndMarker + "\n")
+	return b.String()
+}
+
+// UpdateManagedSection 将受管区块写入文件
+// 文件已有区块时原位替换，否则追加到末尾；文件不存在时创建
+// 返回: 文件内容是否发生变化
+func UpdateManagedSection(file, section string) (bool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	old := string(data)
+
+	var updated string
+	begin := strings.Index(old, IndexBeginMarker)
+	end := strings.Index(old, IndexEndMarker)
+	switch {
+	case begin >= 0 && end > begin:
+		// 关键步骤：仅替换标记之间的内容，保留用户内容
+		rest := old[end+len(IndexEndMarker):]
+		rest = strings.TrimPrefix(rest, "\n")
+		updated = old[:begin] + section + rest
+	case begin >= 0 || end >= 0:
+		return false, fmt.Errorf("%s: unbalanced skillsync markers, fix or remove them manually", file)
+	case strings.TrimSpace(old) == "":
+		updated = section
+	default:
+		updated = strings.TrimRight(old, "\n") + "\n\n" + section
+	}
+
+	if updated == old {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(file, []byte(updated), 0644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
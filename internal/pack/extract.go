@@ -0,0 +1,162 @@
+package pack
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IsArchive 判断路径是否为支持的 skill 归档
+func IsArchive(path string) bool {
+	lower := strings.ToLower(path)
+	return strings.HasSuffix(lower, ".zip") || strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz")
+}
+
+// Extract 将归档解压到目标目录
+// 拒绝绝对路径、.. 路径逃逸以及链接条目，仅解压普通文件与目录
+func Extract(archive, destDir string) error {
+	lower := strings.ToLower(archive)
+	if strings.HasSuffix(lower, ".zip") {
+		return extractZip(archive, destDir)
+	}
+	return extractTarGz(archive, destDir)
+}
+
+// safeJoin 计算条目的目标路径，并确保其位于 destDir 内
+func safeJoin(destDir, name string) (string, error) {
+	name = filepath.FromSlash(name)
+	if filepath.IsAbs(name) {
+		return "", fmt.Errorf("archive entry has absolute path: %s", name)
+	}
+	target := filepath.Join(destDir, name)
+	rel, err := filepath.Rel(destDir, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("archive entry escapes destination: %s", name)
+	}
+	return target, nil
+}
+
+// writeEntry 写入单个文件条目
+func writeEntry(target string, mode os.FileMode, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, normalizeMode(mode))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// extractZip 解压 zip 归档
+func extractZip(archive, destDir string) error {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer zr.Close()
+
+	for _, zf := range zr.File {
+		target, err := safeJoin(destDir, zf.Name)
+		if err != nil {
+			return err
+		}
+		mode := zf.Mode()
+		switch {
+		case mode.IsDir():
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case mode.IsRegular():
+			rc, err := zf.Open()
+			if err != nil {
+				return err
+			}
+			err = writeEntry(target, mode, rc)
+			rc.Close()
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported archive entry type: %s", zf.Name)
+		}
+	}
+	return nil
+}
+
+// extractTarGz 解压 tar.gz 归档
+func extractTarGz(archive, destDir string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		target, err := safeJoin(destDir, header.Name)
+		if err != nil {
+			return err
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeEntry(target, os.FileMode(header.Mode), tr); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported archive entry type: %s", header.Name)
+		}
+	}
+}
+
+// CheckManifest 校验归档与旁边清单 <archive>.manifest.json 中记录的 SHA-256 是否一致
+// 返回: 清单；清单不存在时返回 nil, nil
+func CheckManifest(archive string) (*Manifest, error) {
+	data, err := os.ReadFile(archive + ManifestSuffix)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	sum, err := hashFile(archive)
+	if err != nil {
+		return nil, err
+	}
+	if sum != manifest.Archive.SHA256 {
+		return &manifest, fmt.Errorf("archive SHA-256 mismatch: manifest %s, actual %s", manifest.Archive.SHA256, sum)
+	}
+	return &manifest, nil
+}
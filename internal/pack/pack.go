@@ -0,0 +1,354 @@
+// Package pack 提供 skill 打包功能，生成可复现的归档文件与清单
+package pack
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlfonsSkills/SkillSync/internal/skill"
+)
+
+// Format 归档格式
+type Format string
+
+const (
+	FormatZip   Format = "zip"
+	FormatTarGz Format = "tar.gz"
+)
+
+// ManifestSuffix 清单文件后缀，写在归档文件旁
+const ManifestSuffix = ".manifest.json"
+
+// normalizedModTime 归档内所有条目使用的固定修改时间（zip 支持的最早时间）
+var normalizedModTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ParseFormat 解析归档格式字符串
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "zip":
+		return FormatZip, nil
+	case "tar.gz", "tgz":
+		return FormatTarGz, nil
+	}
+	return "", fmt.Errorf("unsupported archive format: %s, valid formats are: zip, tar.gz", s)
+}
+
+// FileEntry 清单中的单个文件
+type FileEntry struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	Mode   string `json:"mode"`
+	SHA256 string `json:"sha256"`
+}
+
+// ArchiveInfo 清单中的归档信息
+type ArchiveInfo struct {
+	File   string `json:"file"`
+	Format Format `json:"format"`
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest 打包清单
+type Manifest struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+	Archive     ArchiveInfo    `json:"archive"`
+	Files       []FileEntry    `json:"files"`
+}
+
+// Options 打包选项
+type Options struct {
+	Format Format            // 归档格式
+	Output string            // 归档输出路径
+	Copy   skill.CopyOptions // 排除规则与符号链接策略（与安装时一致）
+}
+
+// file 表示待打包的文件
+type file struct {
+	rel  string // 归档内相对路径（使用 /）
+	path string // 本地路径
+	mode os.FileMode
+	size int64
+}
+
+// Pack 将 skill 目录打包为归档文件，并在旁边写入清单
+// 条目按路径排序，修改时间与权限位统一规范化，保证相同内容产生相同归档
+func Pack(skillDir string, opts Options) (*Manifest, error) {
+	if err := skill.ValidateSkillDir(skillDir); err != nil {
+		return nil, err
+	}
+	meta, err := skill.ReadMetadata(skillDir)
+	if err != nil {
+		return nil, err
+	}
+	info := skill.LoadSkillInfo(skillDir, filepath.Base(skillDir))
+
+	// 输出路径位于 skill 目录内时，不能把上一次的归档与清单打包进去
+	// 遍历使用真实路径，输出目录同样解析符号链接后再比较
+	skip := map[string]bool{}
+	if abs, err := filepath.Abs(opts.Output); err == nil {
+		if realDir, err := filepath.EvalSymlinks(filepath.Dir(abs)); err == nil {
+			abs = filepath.Join(realDir, filepath.Base(abs))
+		}
+		skip[abs] = true
+		skip[abs+ManifestSuffix] = true
+	}
+
+	files, err := collectFiles(skillDir, opts.Copy, skip)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{
+		Name:        info.Name,
+		Description: meta.Description,
+		Metadata:    meta.Raw,
+		Archive:     ArchiveInfo{File: filepath.Base(opts.Output), Format: opts.Format},
+	}
+	for _, f := range files {
+		sum, err := hashFile(f.path)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, FileEntry{
+			Path:   f.rel,
+			Size:   f.size,
+			Mode:   fmt.Sprintf("%04o", normalizeMode(f.mode)),
+			SHA256: sum,
+		})
+	}
+
+	if err := writeArchive(opts.Output, opts.Format, info.Name, files); err != nil {
+		return nil, err
+	}
+	archiveSum, err := hashFile(opts.Output)
+	if err != nil {
+		return nil, err
+	}
+	manifest.Archive.SHA256 = archiveSum
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(opts.Output+ManifestSuffix, append(data, '\n'), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// collectFiles 收集需要打包的文件（按相对路径排序）
+// 归档中不包含符号链接：reject 策略下遇到链接即报错，其余策略打包链接指向的内容，
+// 与安装一样拒绝指向 skill 目录之外的链接
+func collectFiles(root string, opts skill.CopyOptions, skip map[string]bool) ([]file, error) {
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve skill directory: %w", err)
+	}
+	c := &collector{root: realRoot, opts: opts, skip: skip, active: make(map[string]bool)}
+	if err := c.walk(realRoot, ""); err != nil {
+		return nil, fmt.Errorf("failed to scan skill directory: %w", err)
+	}
+
+	sort.Slice(c.files, func(i, j int) bool { return c.files[i].rel < c.files[j].rel })
+	return c.files, nil
+}
+
+// collector 保存一次 collectFiles 调用的状态
+type collector struct {
+	root   string            // skill 目录真实路径
+	opts   skill.CopyOptions // 排除规则与符号链接策略
+	skip   map[string]bool   // 需要跳过的文件（上一次的归档与清单）
+	active map[string]bool   // 当前递归链上的目录真实路径，用于检测链接循环
+	files  []file
+}
+
+// walk 递归收集目录中的文件，rel 为目录相对 skill 根目录的路径
+func (c *collector) walk(dir, rel string) error {
+	if c.active[dir] {
+		return fmt.Errorf("refusing symlink %s: creates a directory cycle", rel)
+	}
+	c.active[dir] = true
+	defer delete(c.active, dir)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		p := filepath.Join(dir, entry.Name())
+		relPath := path.Join(rel, entry.Name())
+
+		switch {
+		case entry.Type()&os.ModeSymlink != 0:
+			// 符号链接按目标类型匹配排除规则
+			isDir := false
+			if info, err := os.Stat(p); err == nil {
+				isDir = info.IsDir()
+			}
+			if c.opts.Excludes(relPath, isDir) {
+				continue
+			}
+			if c.opts.Symlinks == skill.SymlinkReject {
+				return fmt.Errorf("refusing symlink %s: symlinks are not allowed", relPath)
+			}
+			target, info, err := skill.ResolveLink(c.root, p, relPath)
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if err := c.walk(target, relPath); err != nil {
+					return err
+				}
+				continue
+			}
+			c.add(target, relPath, info)
+		case entry.IsDir():
+			if c.opts.Excludes(relPath, true) {
+				continue
+			}
+			if err := c.walk(p, relPath); err != nil {
+				return err
+			}
+		case entry.Type().IsRegular():
+			if c.opts.Excludes(relPath, false) {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			c.add(p, relPath, info)
+		}
+	}
+	return nil
+}
+
+// add 记录一个待打包的文件
+func (c *collector) add(p, rel string, info os.FileInfo) {
+	if c.skip[p] {
+		return
+	}
+	c.files = append(c.files, file{rel: rel, path: p, mode: info.Mode(), size: info.Size()})
+}
+
+// writeArchive 按格式写入归档，条目统一放在 <name>/ 前缀下
+func writeArchive(output string, format Format, name string, files []file) error {
+	out, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer out.Close()
+
+	switch format {
+	case FormatZip:
+		err = writeZip(out, name, files)
+	case FormatTarGz:
+		err = writeTarGz(out, name, files)
+	default:
+		err = fmt.Errorf("unsupported archive format: %s", format)
+	}
+	if err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// writeZip 写入 zip 归档
+func writeZip(w io.Writer, name string, files []file) error {
+	zw := zip.NewWriter(w)
+	for _, f := range files {
+		header := &zip.FileHeader{
+			Name:     name + "/" + f.rel,
+			Method:   zip.Deflate,
+			Modified: normalizedModTime,
+		}
+		header.SetMode(normalizeMode(f.mode))
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if err := copyFileTo(fw, f.path); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// writeTarGz 写入 tar.gz 归档
+func writeTarGz(w io.Writer, name string, files []file) error {
+	gw, err := gzip.NewWriterLevel(w, gzip.BestCompression)
+	if err != nil {
+		return err
+	}
+	// 关键步骤：gzip 头不写入文件名与时间，保证可复现
+	gw.ModTime = time.Time{}
+
+	tw := tar.NewWriter(gw)
+	for _, f := range files {
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name + "/" + f.rel,
+			Size:     f.size,
+			Mode:     int64(normalizeMode(f.mode)),
+			ModTime:  normalizedModTime,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if err := copyFileTo(tw, f.path); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// normalizeMode 规范化权限位：可执行文件为 0755，其余为 0644
+func normalizeMode(mode os.FileMode) os.FileMode {
+	if mode&0111 != 0 {
+		return 0755
+	}
+	return 0644
+}
+
+// copyFileTo 将文件内容写入 writer
+func copyFileTo(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// hashFile 计算文件的 SHA-256
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
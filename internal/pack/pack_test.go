@@ -0,0 +1,348 @@
+package pack
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AlfonsSkills/SkillSync/internal/skill"
+)
+
+// writeTestSkill 创建包含 SKILL.md、脚本与子目录文件的 skill
+func writeTestSkill(t *testing.T, dir string, fileMode, execMode os.FileMode, modTime time.Time) {
+	t.Helper()
+	files := map[string]struct {
+		content string
+		mode    os.FileMode
+	}{
+		"SKILL.md":          {"---\nname: demo\ndescription: Demo skill\n---\n\nBody\n", fileMode},
+		"scripts/run.sh":    {"#!/bin/sh\necho hi\n", execMode},
+		"references/doc.md": {"# Doc\n", fileMode},
+	}
+	for rel, f := range files {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(f.content), f.mode); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chmod(path, f.mode); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// sha256Hex 计算文件的 SHA-256
+func sha256Hex(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestPackDeterministic(t *testing.T) {
+	for _, format := range []Format{FormatZip, FormatTarGz} {
+		t.Run(string(format), func(t *testing.T) {
+			first := filepath.Join(t.TempDir(), "demo")
+			second := filepath.Join(t.TempDir(), "demo")
+			writeTestSkill(t, first, 0644, 0755, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+			writeTestSkill(t, second, 0600, 0700, time.Date(2024, 6, 7, 8, 9, 10, 0, time.UTC))
+
+			out1 := filepath.Join(t.TempDir(), "demo."+string(format))
+			out2 := filepath.Join(t.TempDir(), "demo."+string(format))
+			if _, err := Pack(first, Options{Format: format, Output: out1}); err != nil {
+				t.Fatalf("Pack() error = %v", err)
+			}
+			if _, err := Pack(second, Options{Format: format, Output: out2}); err != nil {
+				t.Fatalf("Pack() error = %v", err)
+			}
+			if a, b := sha256Hex(t, out1), sha256Hex(t, out2); a != b {
+				t.Errorf("archives differ: %s vs %s", a, b)
+			}
+			if a, b := sha256Hex(t, out1+ManifestSuffix), sha256Hex(t, out2+ManifestSuffix); a != b {
+				t.Errorf("manifests differ: %s vs %s", a, b)
+			}
+		})
+	}
+}
+
+func TestPackManifest(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "demo")
+	writeTestSkill(t, dir, 0644, 0755, time.Now())
+	if err := os.WriteFile(filepath.Join(dir, "notes.tmp"), []byte("scratch"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(t.TempDir(), "demo.zip")
+
+	// 排除规则与安装一致
+	manifest, err := Pack(dir, Options{Format: FormatZip, Output: out, Copy: copyOptionsExcluding(t, "*.tmp")})
+	if err != nil {
+		t.Fatalf("Pack() error = %v", err)
+	}
+
+	wantPaths := []string{"SKILL.md", "references/doc.md", "scripts/run.sh"}
+	if len(manifest.Files) != len(wantPaths) {
+		t.Fatalf("manifest files = %v, want %v", manifest.Files, wantPaths)
+	}
+	for i, f := range manifest.Files {
+		if f.Path != wantPaths[i] {
+			t.Errorf("file %d = %s, want %s", i, f.Path, wantPaths[i])
+		}
+		if want := sha256Hex(t, filepath.Join(dir, filepath.FromSlash(f.Path))); f.SHA256 != want {
+			t.Errorf("%s sha256 = %s, want %s", f.Path, f.SHA256, want)
+		}
+		wantMode := "0644"
+		if f.Path == "scripts/run.sh" {
+			wantMode = "0755"
+		}
+		if f.Mode != wantMode {
+			t.Errorf("%s mode = %s, want %s", f.Path, f.Mode, wantMode)
+		}
+	}
+	if manifest.Name != "demo" || manifest.Description != "Demo skill" {
+		t.Errorf("manifest name/description = %q/%q", manifest.Name, manifest.Description)
+	}
+	if want := sha256Hex(t, out); manifest.Archive.SHA256 != want {
+		t.Errorf("archive sha256 = %s, want %s", manifest.Archive.SHA256, want)
+	}
+
+	if _, err := CheckManifest(out); err != nil {
+		t.Errorf("CheckManifest() error = %v", err)
+	}
+	if err := os.WriteFile(out, []byte("tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CheckManifest(out); err == nil || !strings.Contains(err.Error(), "mismatch") {
+		t.Errorf("CheckManifest(tampered) error = %v, want mismatch", err)
+	}
+}
+
+func TestPackExtractRoundTrip(t *testing.T) {
+	for _, format := range []Format{FormatZip, FormatTarGz} {
+		t.Run(string(format), func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "demo")
+			writeTestSkill(t, dir, 0644, 0755, time.Now())
+			out := filepath.Join(t.TempDir(), "demo."+string(format))
+			if _, err := Pack(dir, Options{Format: format, Output: out}); err != nil {
+				t.Fatalf("Pack() error = %v", err)
+			}
+
+			dest := t.TempDir()
+			if err := Extract(out, dest); err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			script := filepath.Join(dest, "demo", "scripts", "run.sh")
+			info, err := os.Stat(script)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Mode().Perm() != 0755 {
+				t.Errorf("extracted script mode = %v, want 0755", info.Mode().Perm())
+			}
+			if got, want := sha256Hex(t, filepath.Join(dest, "demo", "SKILL.md")), sha256Hex(t, filepath.Join(dir, "SKILL.md")); got != want {
+				t.Errorf("extracted SKILL.md differs")
+			}
+		})
+	}
+}
+
+// tarEntry 构造测试归档的条目
+type tarEntry struct {
+	name     string
+	typeflag byte
+	linkname string
+}
+
+// writeTestTarGz 写入包含指定条目的 tar.gz
+func writeTestTarGz(t *testing.T, path string, entries []tarEntry) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	for _, e := range entries {
+		header := &tar.Header{Name: e.name, Typeflag: e.typeflag, Linkname: e.linkname, Mode: 0644}
+		content := ""
+		if e.typeflag == tar.TypeReg {
+			content = "data"
+			header.Size = int64(len(content))
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeTestZip 写入包含指定条目的 zip，mode 为条目权限与类型
+func writeTestZip(t *testing.T, path, name string, mode os.FileMode) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	header := &zip.FileHeader{Name: name}
+	header.SetMode(mode)
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("data")); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractRejectsUnsafeEntries(t *testing.T) {
+	tarTests := []struct {
+		name    string
+		entry   tarEntry
+		wantErr string
+	}{
+		{"absolute path", tarEntry{name: "/etc/evil", typeflag: tar.TypeReg}, "absolute path"},
+		{"parent escape", tarEntry{name: "demo/../../evil", typeflag: tar.TypeReg}, "escapes destination"},
+		{"symlink", tarEntry{name: "demo/link", typeflag: tar.TypeSymlink, linkname: "/etc/passwd"}, "unsupported archive entry type"},
+		{"hard link", tarEntry{name: "demo/link", typeflag: tar.TypeLink, linkname: "demo/SKILL.md"}, "unsupported archive entry type"},
+	}
+	for _, tt := range tarTests {
+		t.Run("tar.gz/"+tt.name, func(t *testing.T) {
+			archive := filepath.Join(t.TempDir(), "evil.tar.gz")
+			writeTestTarGz(t, archive, []tarEntry{tt.entry})
+			dest := filepath.Join(t.TempDir(), "dest")
+			err := Extract(archive, dest)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Extract() error = %v, want %q", err, tt.wantErr)
+			}
+			if _, err := os.Stat(filepath.Join(filepath.Dir(dest), "evil")); !os.IsNotExist(err) {
+				t.Error("escaping entry was written outside the destination")
+			}
+		})
+	}
+
+	zipTests := []struct {
+		name    string
+		entry   string
+		mode    os.FileMode
+		wantErr string
+	}{
+		{"absolute path", "/etc/evil", 0644, "absolute path"},
+		{"parent escape", "../evil", 0644, "escapes destination"},
+		{"symlink", "demo/link", os.ModeSymlink | 0777, "unsupported archive entry type"},
+	}
+	for _, tt := range zipTests {
+		t.Run("zip/"+tt.name, func(t *testing.T) {
+			archive := filepath.Join(t.TempDir(), "evil.zip")
+			writeTestZip(t, archive, tt.entry, tt.mode)
+			dest := filepath.Join(t.TempDir(), "dest")
+			err := Extract(archive, dest)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Extract() error = %v, want %q", err, tt.wantErr)
+			}
+			if _, err := os.Stat(filepath.Join(filepath.Dir(dest), "evil")); !os.IsNotExist(err) {
+				t.Error("escaping entry was written outside the destination")
+			}
+		})
+	}
+}
+
+// copyOptionsExcluding 返回附加了忽略规则的默认拷贝选项
+func copyOptionsExcluding(t *testing.T, patterns ...string) skill.CopyOptions {
+	t.Helper()
+	rules, err := skill.ParseIgnore([]byte(strings.Join(patterns, "\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := skill.DefaultCopyOptions()
+	opts.Ignore = rules
+	return opts
+}
+
+func TestPackSymlinks(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  skill.SymlinkPolicy
+		link    string // 相对 scripts/ 的链接目标
+		want    []string
+		wantErr string
+	}{
+		{"dereference file", skill.SymlinkDereference, "run.sh", []string{"SKILL.md", "references/doc.md", "scripts/run.sh", "scripts/start.sh"}, ""},
+		{"dereference directory", skill.SymlinkDereference, "../references", []string{"SKILL.md", "references/doc.md", "scripts/run.sh", "scripts/start.sh/doc.md"}, ""},
+		{"reject", skill.SymlinkReject, "run.sh", nil, "symlinks are not allowed"},
+		{"outside the skill", skill.SymlinkDereference, "../../outside", nil, "outside the skill directory"},
+		{"directory cycle", skill.SymlinkDereference, "..", nil, "cycle"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := t.TempDir()
+			if err := os.WriteFile(filepath.Join(parent, "outside"), []byte("secret"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			dir := filepath.Join(parent, "demo")
+			writeTestSkill(t, dir, 0644, 0755, time.Now())
+			if err := os.Symlink(tt.link, filepath.Join(dir, "scripts", "start.sh")); err != nil {
+				t.Fatal(err)
+			}
+
+			opts := skill.DefaultCopyOptions()
+			opts.Symlinks = tt.policy
+			out := filepath.Join(t.TempDir(), "demo.zip")
+			manifest, err := Pack(dir, Options{Format: FormatZip, Output: out, Copy: opts})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Pack() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Pack() error = %v", err)
+			}
+
+			var got []string
+			for _, f := range manifest.Files {
+				got = append(got, f.Path)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("packed files = %v, want %v", got, tt.want)
+			}
+
+			// 链接以目标内容打包，解包后为普通文件
+			dest := t.TempDir()
+			if err := Extract(out, dest); err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if info, err := os.Lstat(filepath.Join(dest, "demo", "scripts", "start.sh")); err != nil || info.Mode()&os.ModeSymlink != 0 {
+				t.Errorf("scripts/start.sh was not packed as content: %v", err)
+			}
+		})
+	}
+}
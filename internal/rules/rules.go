@@ -0,0 +1,273 @@
+// Package rules 发现项目中各工具的规则文件，并转换为符合规范的 skill 目录
+// 支持 .cursorrules、.cursor/rules/*.mdc、.clinerules（文件或目录）、.roo/rules/ 以及 Copilot 指令文件
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/AlfonsSkills/SkillSync/internal/skill"
+	"github.com/AlfonsSkills/SkillSync/internal/yaml"
+)
+
+// Kind 规则来源类型
+type Kind string
+
+const (
+	KindCursorLegacy Kind = "cursorrules" // .cursorrules
+	KindCursor       Kind = "cursor"      // .cursor/rules/*.mdc
+	KindCline        Kind = "cline"       // .clinerules 或 .clinerules/*.md
+	KindRoo          Kind = "roo"         // .roo/rules/*
+	KindCopilot      Kind = "copilot"     // .github/copilot-instructions.md、.github/instructions/*.instructions.md
+)
+
+// RuleFile 发现的单个规则文件
+type RuleFile struct {
+	Kind        Kind
+	Path        string   // 绝对路径
+	RelPath     string   // 相对项目根目录的路径（/ 分隔）
+	Name        string   // 生成的 skill 名称
+	Description string   // 生成的 skill 描述
+	Globs       []string // 规则适用的文件模式（Cursor globs / Copilot applyTo）
+	AlwaysApply bool
+	Body        string // 去掉 frontmatter 后的规则正文
+}
+
+// ruleLocation 规则文件位置定义
+type ruleLocation struct {
+	kind Kind
+	path string // 相对项目根目录
+	dir  bool   // 目录时读取其中的规则文件
+	exts []string
+}
+
+// locations 按工具列出的规则位置
+var locations = []ruleLocation{
+	{kind: KindCursorLegacy, path: ".cursorrules"},
+	{kind: KindCursor, path: ".cursor/rules", dir: true, exts: []string{".mdc", ".md"}},
+	{kind: KindCline, path: ".clinerules"},
+	{kind: KindCline, path: ".clinerules", dir: true, exts: []string{".md", ".txt"}},
+	{kind: KindRoo, path: ".roo/rules", dir: true, exts: []string{".md", ".txt"}},
+	{kind: KindRoo, path: ".roorules"},
+	{kind: KindCopilot, path: ".github/copilot-instructions.md"},
+	{kind: KindCopilot, path: ".github/instructions", dir: true, exts: []string{".instructions.md"}},
+}
+
+// Discover 查找项目根目录下的所有规则文件
+// 返回的规则名称已去重（冲突时追加来源类型后缀）
+func Discover(root string) ([]RuleFile, error) {
+	var found []RuleFile
+	for _, loc := range locations {
+		base := filepath.Join(root, filepath.FromSlash(loc.path))
+		info, err := os.Stat(base)
+		if err != nil || info.IsDir() != loc.dir {
+			continue
+		}
+
+		if !loc.dir {
+			rule, err := loadRule(root, base, loc.kind)
+			if err != nil {
+				return nil, err
+			}
+			found = append(found, rule)
+			continue
+		}
+
+		err = filepath.WalkDir(base, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !hasExt(d.Name(), loc.exts) {
+				return err
+			}
+			rule, err := loadRule(root, path, loc.kind)
+			if err != nil {
+				return err
+			}
+			found = append(found, rule)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dedupeNames(found)
+	return found, nil
+}
+
+// hasExt 判断文件名是否以任一扩展名结尾
+func hasExt(name string, exts []string) bool {
+	lower := strings.ToLower(name)
+	for _, ext := range exts {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadRule 读取并解析单个规则文件
+func loadRule(root, path string, kind Kind) (RuleFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return RuleFile{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	rel, _ := filepath.Rel(root, path)
+	rule := RuleFile{Kind: kind, Path: path, RelPath: filepath.ToSlash(rel)}
+
+	front, body, ok := skill.SplitFrontmatter(content)
+	rule.Body = strings.TrimSpace(string(body))
+	if ok {
+		if raw, err := yaml.ParseMap(front); err == nil {
+			rule.Description = yaml.String(raw, "description")
+			rule.Globs = yaml.StringSlice(raw["globs"])
+			if applyTo := yaml.String(raw, "applyTo"); applyTo != "" && len(rule.Globs) == 0 {
+				rule.Globs = yaml.StringSlice(applyTo)
+			}
+			rule.AlwaysApply = yaml.Bool(raw["alwaysApply"])
+		}
+	}
+
+	heading := firstHeading(rule.Body)
+	rule.Name = ruleName(rule, heading)
+	if rule.Description == "" {
+		rule.Description = ruleDescription(rule, heading)
+	}
+	return rule, nil
+}
+
+// headingPattern 匹配 Markdown 标题
+var headingPattern = regexp.MustCompile(`(?m)^#{1,3}\s+(.+?)\s*#*\s*$`)
+
+// firstHeading 返回正文中的第一个标题
+func firstHeading(body string) string {
+	if m := headingPattern.FindStringSubmatch(body); m != nil {
+		return strings.TrimSpace(m[1])
+	}
+	return ""
+}
+
+// ruleName 生成 skill 名称
+// 目录中的规则使用文件名；单文件规则（.cursorrules 等）优先使用第一个标题
+func ruleName(rule RuleFile, heading string) string {
+	base := filepath.Base(rule.Path)
+	for _, ext := range []string{".instructions.md", ".mdc", ".md", ".txt"} {
+		if strings.HasSuffix(strings.ToLower(base), ext) {
+			base = base[:len(base)-len(ext)]
+			break
+		}
+	}
+
+	candidates := []string{base}
+	if strings.HasPrefix(base, ".") || base == "copilot-instructions" {
+		candidates = []string{heading, string(rule.Kind) + "-rules"}
+	}
+	for _, c := range candidates {
+		if name := Slugify(c); name != "" {
+			return name
+		}
+	}
+	return string(rule.Kind) + "-rules"
+}
+
+// ruleDescription 生成 skill 描述：标题与第一段文字，最后回退为来源说明
+func ruleDescription(rule RuleFile, heading string) string {
+	var parts []string
+	if heading != "" {
+		parts = append(parts, strings.TrimSuffix(heading, "."))
+	}
+	for _, para := range strings.Split(rule.Body, "\n\n") {
+		para = strings.TrimSpace(para)
+		if para == "" || strings.HasPrefix(para, "#") || strings.HasPrefix(para, "```") || strings.HasPrefix(para, "-") || strings.HasPrefix(para, "*") {
+			continue
+		}
+		sentence := strings.Join(strings.Fields(para), " ")
+		if idx := strings.Index(sentence, ". "); idx > 0 {
+			sentence = sentence[:idx]
+		}
+		parts = append(parts, strings.TrimSuffix(sentence, "."))
+		break
+	}
+
+	desc := strings.Join(parts, ". ")
+	if desc == "" {
+		desc = fmt.Sprintf("Project rules imported from %s", rule.RelPath)
+	}
+	if len(rule.Globs) > 0 {
+		desc += fmt.Sprintf(". Use when working on files matching %s", strings.Join(rule.Globs, ", "))
+	}
+	if len(desc) > 1024 {
+		desc = desc[:1021] + "..."
+	}
+	return desc
+}
+
+// slugPattern 非法字符
+var slugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify 将任意文本转换为符合规范的 skill 名称（小写字母、数字与单个连字符，最长 64）
+func Slugify(s string) string {
+	slug := strings.Trim(slugPattern.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if len(slug) > 64 {
+		slug = strings.TrimRight(slug[:64], "-")
+	}
+	return slug
+}
+
+// dedupeNames 为重名规则追加来源类型后缀
+func dedupeNames(found []RuleFile) {
+	counts := make(map[string]int)
+	for _, r := range found {
+		counts[r.Name]++
+	}
+	used := make(map[string]bool)
+	for i := range found {
+		name := found[i].Name
+		if counts[name] > 1 {
+			name = Slugify(name + "-" + string(found[i].Kind))
+		}
+		for n := 2; used[name]; n++ {
+			name = Slugify(fmt.Sprintf("%s-%d", found[i].Name, n))
+		}
+		used[name] = true
+		found[i].Name = name
+	}
+}
+
+// WriteSkill 将规则写为 <parentDir>/<name>/SKILL.md
+// 返回: skill 目录
+func WriteSkill(rule RuleFile, parentDir string) (string, error) {
+	if err := skill.ValidateName(rule.Name); err != nil {
+		return "", err
+	}
+	dir := filepath.Join(parentDir, rule.Name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "name: %s\n", rule.Name)
+	fmt.Fprintf(&b, "description: %s\n", skill.QuoteYAMLScalar(rule.Description))
+	b.WriteString("metadata:\n")
+	fmt.Fprintf(&b, "  imported-from: %s\n", skill.QuoteYAMLScalar(rule.RelPath))
+	if len(rule.Globs) > 0 {
+		quoted := make([]string, len(rule.Globs))
+		for i, g := range rule.Globs {
+			quoted[i] = fmt.Sprintf("%q", g)
+		}
+		fmt.Fprintf(&b, "  globs: [%s]\n", strings.Join(quoted, ", "))
+	}
+	if rule.AlwaysApply {
+		b.WriteString("  alwaysApply: true\n")
+	}
+	b.WriteString("---\n\n")
+	b.WriteString(rule.Body)
+	b.WriteString("\n")
+
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(b.String()), 0644); err != nil {
+		return "", fmt.Errorf("failed to write SKILL.md: %w", err)
+	}
+	return dir, nil
+}
@@ -0,0 +1,75 @@
+package scan
+
+import "regexp"
+
+// lineRule 逐行匹配的扫描规则
+type lineRule struct {
+	id        string
+	severity  Severity
+	pattern   *regexp.Regexp
+	message   string
+	skillOnly bool   // 仅检查 SKILL.md
+	unless    string // 同一行已命中该规则时不再报告
+}
+
+// lineRules 内置规则
+var lineRules = []lineRule{
+	{
+		id:       "pipe-to-shell",
+		severity: SeverityHigh,
+		pattern:  regexp.MustCompile(`\b(curl|wget)\b[^|]*\|\s*(sudo\s+)?(ba|z|da)?sh\b`),
+		message:  "downloads and executes a remote script",
+	},
+	{
+		id:       "destructive-rm",
+		severity: SeverityHigh,
+		pattern:  regexp.MustCompile(`\brm\s+(-[a-zA-Z]*[rR][a-zA-Z]*\s+)+(/|~|\$HOME|\$\{HOME\})(\s|/?\*|/?$|")`),
+		message:  "recursively deletes the root or home directory",
+	},
+	{
+		id:       "recursive-rm",
+		severity: SeverityMedium,
+		pattern:  regexp.MustCompile(`\brm\s+-[a-zA-Z]*([rR][a-zA-Z]*f|f[a-zA-Z]*[rR])`),
+		message:  "uses rm -rf",
+		unless:   "destructive-rm",
+	},
+	{
+		id:       "credential-path",
+		severity: SeverityHigh,
+		pattern:  regexp.MustCompile(`(~|\$HOME|\$\{HOME\}|/home/[^/\s]+|/Users/[^/\s]+)/\.(ssh|aws|gnupg|kube|docker/config\.json|netrc|npmrc|pypirc|git-credentials|config/gh)\b|\bid_(rsa|ed25519|ecdsa)\b`),
+		message:  "accesses credential files",
+	},
+	{
+		id:       "encoded-blob",
+		severity: SeverityMedium,
+		pattern:  regexp.MustCompile(`[A-Za-z0-9+/]{200,}={0,2}`),
+		message:  "contains a long base64-encoded blob",
+	},
+	{
+		id:       "decode-exec",
+		severity: SeverityHigh,
+		pattern:  regexp.MustCompile(`base64\s+(-d|--decode)\b[^|]*\|\s*(ba|z)?sh\b|\beval\s*\(?\s*["'$(]*\s*(atob|base64)`),
+		message:  "decodes and executes an encoded payload",
+	},
+	{
+		id:        "prompt-injection",
+		severity:  SeverityHigh,
+		pattern:   regexp.MustCompile(`(?i)\b(ignore|disregard|forget)\s+(all\s+|any\s+)?(the\s+)?(previous|prior|above|earlier|system)\s+(instructions|prompts?|rules)`),
+		message:   "prompt-injection phrase",
+		skillOnly: true,
+	},
+	{
+		id:        "hidden-instructions",
+		severity:  SeverityMedium,
+		pattern:   regexp.MustCompile(`(?i)\b(do not|don't|never)\s+(tell|inform|mention\s+(this\s+)?to|reveal\s+(this\s+)?to)\s+the\s+user\b`),
+		message:   "asks the agent to hide actions from the user",
+		skillOnly: true,
+	},
+	{
+		id:        "hidden-unicode",
+		severity:  SeverityMedium,
+		pattern:   regexp.MustCompile(`[\x{200b}-\x{200f}\x{202a}-\x{202e}\x{2066}-\x{2069}\x{e0000}-\x{e007f}]`),
+		message:   "contains invisible or bidirectional control characters",
+		skillOnly: true,
+	},
+}
@@ -0,0 +1,226 @@
+// Package scan 提供安装前的 skill 内容安全扫描
+// 按规则检查脚本中的危险命令、凭据路径、编码数据块、二进制文件以及 SKILL.md 中的提示词注入
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/AlfonsSkills/SkillSync/internal/skill"
+)
+
+// Severity 风险等级
+type Severity int
+
+const (
+	SeverityNone Severity = iota
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+)
+
+// String 返回等级名称
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	default:
+		return "none"
+	}
+}
+
+// ParseSeverity 解析等级名称
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "low":
+		return SeverityLow, nil
+	case "medium":
+		return SeverityMedium, nil
+	case "high":
+		return SeverityHigh, nil
+	default:
+		return SeverityNone, fmt.Errorf("unknown severity: %s (supported: low, medium, high)", s)
+	}
+}
+
+// maxLineSize 单行最大长度，超出时停止扫描该文件
+const maxLineSize = 1 << 20
+
+// Finding 单条扫描结果
+type Finding struct {
+	Severity Severity
+	Rule     string // 规则标识
+	File     string // 相对 skill 目录的路径
+	Line     int    // 行号，0 表示整个文件
+	Message  string
+}
+
+// Location 返回 file:line 形式的位置
+func (f Finding) Location() string {
+	if f.Line > 0 {
+		return fmt.Sprintf("%s:%d", f.File, f.Line)
+	}
+	return f.File
+}
+
+// Report 单个 skill 的扫描报告
+type Report struct {
+	Skill    string
+	Findings []Finding
+}
+
+// Max 返回报告中的最高风险等级
+func (r Report) Max() Severity {
+	max := SeverityNone
+	for _, f := range r.Findings {
+		if f.Severity > max {
+			max = f.Severity
+		}
+	}
+	return max
+}
+
+// ScanSkill 扫描 skill 实际会被安装的内容
+// 先按 opts 将 skill 拷贝到临时目录（与安装相同的排除规则、符号链接策略与目录边界检查），
+// 再扫描拷贝结果，符号链接指向的内容因此也会被扫描
+func ScanSkill(s skill.SkillInfo, opts skill.CopyOptions) (Report, error) {
+	report := Report{Skill: s.Name}
+	stage, err := os.MkdirTemp("", "skillsync-scan-*")
+	if err != nil {
+		return report, err
+	}
+	defer os.RemoveAll(stage)
+
+	staged := filepath.Join(stage, "skill")
+	if err := skill.CopyDir(s.Path, staged, opts); err != nil {
+		return report, err
+	}
+
+	err = walk(staged, "", func(path, rel string) error {
+		findings, err := scanFile(path, rel)
+		if err != nil {
+			return err
+		}
+		report.Findings = append(report.Findings, findings...)
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+
+	// 高风险优先，同等级按位置排序
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		a, b := report.Findings[i], report.Findings[j]
+		if a.Severity != b.Severity {
+			return a.Severity > b.Severity
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return report, nil
+}
+
+// walk 遍历拷贝结果中的普通文件
+// preserve 策略保留的链接指向 skill 内部，其目标文件本身也会被遍历
+func walk(dir, rel string, fn func(path, rel string) error) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory: %w", err)
+	}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		relPath := filepath.ToSlash(filepath.Join(rel, entry.Name()))
+		if entry.IsDir() {
+			if err := walk(path, relPath, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		if err := fn(path, relPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanFile 扫描单个文件
+func scanFile(path, rel string) ([]Finding, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	head := make([]byte, 8192)
+	n, _ := f.Read(head)
+	head = head[:n]
+
+	// 二进制文件不做逐行检查
+	if kind := binaryKind(head); kind != "" {
+		severity := SeverityLow
+		if kind != "binary data" {
+			severity = SeverityHigh
+		}
+		return []Finding{{Severity: severity, Rule: "binary", File: rel, Message: "bundled " + kind}}, nil
+	}
+
+	if _, err := f.Seek(0, 0); err != nil {
+		return nil, err
+	}
+	isSkillFile := filepath.Base(rel) == "SKILL.md"
+
+	var findings []Finding
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		matched := make(map[string]bool)
+		for _, r := range lineRules {
+			if (r.skillOnly && !isSkillFile) || matched[r.unless] {
+				continue
+			}
+			if r.pattern.MatchString(line) {
+				matched[r.id] = true
+				findings = append(findings, Finding{Severity: r.severity, Rule: r.id, File: rel, Line: lineNo, Message: r.message})
+			}
+		}
+	}
+	// 超长行（如单行编码数据）超出缓冲区时按编码数据处理
+	if err := scanner.Err(); err != nil {
+		findings = append(findings, Finding{Severity: SeverityMedium, Rule: "encoded-blob", File: rel, Line: lineNo + 1, Message: "very long line, possibly an encoded payload"})
+	}
+	return findings, nil
+}
+
+// binaryKind 根据文件头判断二进制类型，文本文件返回空字符串
+func binaryKind(head []byte) string {
+	switch {
+	case bytes.HasPrefix(head, []byte("\x7fELF")):
+		return "ELF executable"
+	case bytes.HasPrefix(head, []byte("MZ")):
+		return "Windows executable"
+	case bytes.HasPrefix(head, []byte{0xcf, 0xfa, 0xed, 0xfe}),
+		bytes.HasPrefix(head, []byte{0xce, 0xfa, 0xed, 0xfe}),
+		bytes.HasPrefix(head, []byte{0xca, 0xfe, 0xba, 0xbe}):
+		return "Mach-O executable"
+	case bytes.IndexByte(head, 0) >= 0:
+		return "binary data"
+	}
+	return ""
+}
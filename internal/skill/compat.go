@@ -0,0 +1,112 @@
+// Package skill 提供 skill 与目标工具的兼容性声明
+package skill
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/AlfonsSkills/SkillSync/internal/yaml"
+)
+
+// SidecarFile skill 目录中的 SkillSync 附加元数据文件
+// 用于不便修改 SKILL.md 的场景，字段与 frontmatter 中的 SkillSync 扩展字段一致
+const SidecarFile = ".skillsync.yaml"
+
+// Compatibility 表示 skill 对目标工具的兼容性声明
+// frontmatter 写法（也可写在顶层或 .skillsync.yaml 中）：
+//
+//	metadata:
+//	  compatible-tools: [claude, codex]   # 白名单，空表示不限制
+//	  incompatible-tools: [cursor]        # 黑名单
+//	  compatibility-reason: Uses Claude allowed-tools
+type Compatibility struct {
+	Tools   []string // 仅兼容这些工具（ToolType），空表示全部
+	Exclude []string // 不兼容的工具（ToolType）
+	Reason  string   // 限制原因，用于预览提示
+}
+
+// IsRestricted 判断是否声明了任何兼容性限制
+func (c Compatibility) IsRestricted() bool {
+	return len(c.Tools) > 0 || len(c.Exclude) > 0
+}
+
+// ToolNames 返回白名单与黑名单中出现的所有工具标识（去重）
+func (c Compatibility) ToolNames() []string {
+	var names []string
+	for _, name := range append(slices.Clone(c.Tools), c.Exclude...) {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Allows 判断 skill 是否可安装到指定工具
+// 返回: 是否允许，以及不允许时的原因
+func (c Compatibility) Allows(tool string) (bool, string) {
+	tool = strings.ToLower(tool)
+	reason := c.Reason
+
+	if slices.Contains(c.Exclude, tool) {
+		if reason == "" {
+			reason = fmt.Sprintf("marked incompatible with %s", tool)
+		}
+		return false, reason
+	}
+	if len(c.Tools) > 0 && !slices.Contains(c.Tools, tool) {
+		if reason == "" {
+			reason = fmt.Sprintf("only supports %s", strings.Join(c.Tools, ", "))
+		}
+		return false, reason
+	}
+	return true, ""
+}
+
+// Compatibility 从元数据解析兼容性声明
+// 查找顺序：顶层字段，其次 metadata 下的字段
+func (m *Metadata) Compatibility() Compatibility {
+	if m == nil {
+		return Compatibility{}
+	}
+	compat := parseCompatibility(m.Raw)
+	if !compat.IsRestricted() {
+		compat = parseCompatibility(yaml.Map(m.Raw, "metadata"))
+	}
+	return compat
+}
+
+// ReadCompatibility 读取 skill 目录的兼容性声明
+// .skillsync.yaml 存在且有声明时优先，否则使用 SKILL.md frontmatter
+func ReadCompatibility(skillDir string, meta *Metadata) Compatibility {
+	if content, err := os.ReadFile(filepath.Join(skillDir, SidecarFile)); err == nil {
+		if raw, err := yaml.ParseMap(content); err == nil {
+			if compat := parseCompatibility(raw); compat.IsRestricted() {
+				return compat
+			}
+		}
+	}
+	return meta.Compatibility()
+}
+
+// parseCompatibility 从映射中解析兼容性字段
+func parseCompatibility(m map[string]any) Compatibility {
+	if m == nil {
+		return Compatibility{}
+	}
+	return Compatibility{
+		Tools:   lowerAll(yaml.StringSlice(m["compatible-tools"])),
+		Exclude: lowerAll(yaml.StringSlice(m["incompatible-tools"])),
+		Reason:  yaml.String(m, "compatibility-reason"),
+	}
+}
+
+// lowerAll 将字符串切片统一转为小写
+func lowerAll(items []string) []string {
+	for i, s := range items {
+		items[i] = strings.ToLower(s)
+	}
+	return items
+}
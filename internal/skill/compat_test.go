@@ -0,0 +1,57 @@
+package skill
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCompatibilityAllows(t *testing.T) {
+	tests := []struct {
+		name   string
+		compat Compatibility
+		tool   string
+		want   bool
+	}{
+		{"unrestricted", Compatibility{}, "codex", true},
+		{"allow-list hit", Compatibility{Tools: []string{"claude"}}, "Claude", true},
+		{"allow-list miss", Compatibility{Tools: []string{"claude"}}, "codex", false},
+		{"excluded", Compatibility{Exclude: []string{"cursor"}}, "cursor", false},
+		{"exclude wins over allow-list", Compatibility{Tools: []string{"cursor"}, Exclude: []string{"cursor"}}, "cursor", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, reason := tt.compat.Allows(tt.tool)
+			if ok != tt.want {
+				t.Errorf("Allows(%q) = %v, want %v", tt.tool, ok, tt.want)
+			}
+			if !ok && reason == "" {
+				t.Error("Allows() returned no reason for a rejection")
+			}
+		})
+	}
+}
+
+func TestCompatibilityToolNames(t *testing.T) {
+	compat := Compatibility{Tools: []string{"claude", "codex"}, Exclude: []string{"codex", "cursor"}}
+	if got, want := compat.ToolNames(), []string{"claude", "codex", "cursor"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ToolNames() = %v, want %v", got, want)
+	}
+	if len(compat.Tools) != 2 {
+		t.Errorf("ToolNames() modified Tools: %v", compat.Tools)
+	}
+}
+
+func TestReadCompatibility(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "demo")
+	writeSkill(t, dir, "name: demo\nmetadata:\n  compatible-tools: [Claude, codex]\n  compatibility-reason: Uses hooks")
+	meta, err := ReadMetadata(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := ReadCompatibility(dir, meta)
+	want := Compatibility{Tools: []string{"claude", "codex"}, Reason: "Uses hooks"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadCompatibility() = %+v, want %+v", got, want)
+	}
+}
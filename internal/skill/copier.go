@@ -5,25 +5,30 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 )
 
 // CopyOptions 拷贝选项
 type CopyOptions struct {
-	ExcludeDirs  []string // 要排除的目录名
-	ExcludeFiles []string // 要排除的文件名
+	ExcludeDirs  []string      // 要排除的目录名
+	ExcludeFiles []string      // 要排除的文件名
+	Ignore       IgnoreRules   // gitignore 风格规则，按相对路径匹配（.skillsyncignore 与 --exclude/--include）
+	Symlinks     SymlinkPolicy // 符号链接策略，为空时使用 SymlinkDereference
 }
 
 // DefaultCopyOptions 返回默认的拷贝选项
 func DefaultCopyOptions() CopyOptions {
 	return CopyOptions{
 		ExcludeDirs:  []string{".git"},
-		ExcludeFiles: []string{".gitignore", ".gitattributes"},
+		ExcludeFiles: []string{".gitignore", ".gitattributes", SidecarFile, ProvenanceFile, IgnoreFile, RepoManifestFile},
+		Symlinks:     SymlinkDereference,
 	}
 }
 
 // CopyDir 将源目录内容拷贝到目标目录
+// 符号链接按 opts.Symlinks 处理，任何指向源目录之外的链接都会被拒绝；文件与目录的权限和修改时间保持不变
 func CopyDir(src, dst string, opts CopyOptions) error {
 	// 获取源目录信息
 	srcInfo, err := os.Stat(src)
@@ -34,46 +39,102 @@ func CopyDir(src, dst string, opts CopyOptions) error {
 		return fmt.Errorf("source is not a directory: %s", src)
 	}
 
-	// 创建目标目录
-	if err := os.MkdirAll(dst, srcInfo.Mode()); err != nil {
+	root, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		return fmt.Errorf("failed to resolve source directory: %w", err)
+	}
+	if opts.Symlinks == "" {
+		opts.Symlinks = SymlinkDereference
+	}
+
+	c := &copier{root: root, opts: opts, active: make(map[string]bool)}
+	return c.copyDir(root, dst, "")
+}
+
+// copier 保存一次 CopyDir 调用的状态
+type copier struct {
+	root   string          // 源目录真实路径
+	opts   CopyOptions     // 拷贝选项
+	active map[string]bool // 当前递归链上的目录真实路径，用于检测链接循环
+}
+
+// copyDir 递归拷贝，rel 为当前目录相对拷贝根目录的路径（用于规则匹配）
+func (c *copier) copyDir(src, dst, rel string) error {
+	realSrc, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		return fmt.Errorf("failed to resolve directory: %w", err)
+	}
+	if c.active[realSrc] {
+		return fmt.Errorf("refusing symlink %s: creates a directory cycle", rel)
+	}
+	c.active[realSrc] = true
+	defer delete(c.active, realSrc)
+
+	srcInfo, err := os.Stat(realSrc)
+	if err != nil {
+		return fmt.Errorf("failed to stat source directory: %w", err)
+	}
+
+	// 创建目标目录（先保证可写，拷贝完成后再还原权限）
+	if err := os.MkdirAll(dst, 0755); err != nil {
 		return fmt.Errorf("failed to create destination directory: %w", err)
 	}
 
 	// 遍历源目录
-	entries, err := os.ReadDir(src)
+	entries, err := os.ReadDir(realSrc)
 	if err != nil {
 		return fmt.Errorf("failed to read source directory: %w", err)
 	}
 
 	for _, entry := range entries {
-		srcPath := filepath.Join(src, entry.Name())
+		srcPath := filepath.Join(realSrc, entry.Name())
 		dstPath := filepath.Join(dst, entry.Name())
-
-		if entry.IsDir() {
+		relPath := path.Join(rel, entry.Name())
+
+		switch {
+		case entry.Type()&os.ModeSymlink != 0:
+			// 符号链接按目标类型匹配排除规则
+			isDir := false
+			if info, err := os.Stat(srcPath); err == nil {
+				isDir = info.IsDir()
+			}
+			if c.opts.Excludes(relPath, isDir) {
+				continue
+			}
+			if err := c.copySymlink(srcPath, dstPath, relPath); err != nil {
+				return err
+			}
+		case entry.IsDir():
 			// 检查是否需要排除目录
-			if shouldExclude(entry.Name(), opts.ExcludeDirs) {
+			if c.opts.Excludes(relPath, true) {
 				continue
 			}
 			// 递归拷贝子目录
-			if err := CopyDir(srcPath, dstPath, opts); err != nil {
+			if err := c.copyDir(srcPath, dstPath, relPath); err != nil {
 				return err
 			}
-		} else {
+		case entry.Type().IsRegular():
 			// 检查是否需要排除文件
-			if shouldExclude(entry.Name(), opts.ExcludeFiles) {
+			if c.opts.Excludes(relPath, false) {
 				continue
 			}
 			// 拷贝文件
 			if err := CopyFile(srcPath, dstPath); err != nil {
 				return err
 			}
+		default:
+			// 跳过设备文件、命名管道等特殊文件
+			continue
 		}
 	}
 
-	return nil
+	if err := os.Chmod(dst, srcInfo.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to set directory mode: %w", err)
+	}
+	return os.Chtimes(dst, srcInfo.ModTime(), srcInfo.ModTime())
 }
 
-// CopyFile 拷贝单个文件
+// CopyFile 拷贝单个文件，保留权限与修改时间
 func CopyFile(src, dst string) error {
 	srcFile, err := os.Open(src)
 	if err != nil {
@@ -95,8 +156,43 @@ func CopyFile(src, dst string) error {
 	if _, err := io.Copy(dstFile, srcFile); err != nil {
 		return fmt.Errorf("failed to copy file content: %w", err)
 	}
+	if err := dstFile.Close(); err != nil {
+		return fmt.Errorf("failed to write destination file: %w", err)
+	}
 
-	return nil
+	// OpenFile 创建时受 umask 影响，显式设置权限
+	if err := os.Chmod(dst, srcInfo.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to set file mode: %w", err)
+	}
+	return os.Chtimes(dst, srcInfo.ModTime(), srcInfo.ModTime())
+}
+
+// Excludes 检查目录或文件是否应被排除
+// 入参: rel 相对拷贝根目录、以 / 分隔的路径，isDir 是否为目录
+func (o CopyOptions) Excludes(rel string, isDir bool) bool {
+	if o.excludesByName(path.Base(rel), isDir) {
+		return true
+	}
+	return o.Ignore.Match(rel, isDir)
+}
+
+// excludesPath 检查路径本身或其任一上级目录是否被排除
+func (o CopyOptions) excludesPath(rel string, isDir bool) bool {
+	parts := strings.Split(rel, "/")
+	for i := 1; i < len(parts); i++ {
+		if o.Excludes(strings.Join(parts[:i], "/"), true) {
+			return true
+		}
+	}
+	return o.Excludes(rel, isDir)
+}
+
+// excludesByName 按名称检查默认排除列表
+func (o CopyOptions) excludesByName(name string, isDir bool) bool {
+	if isDir {
+		return shouldExclude(name, o.ExcludeDirs)
+	}
+	return shouldExclude(name, o.ExcludeFiles)
 }
 
 // shouldExclude 检查名称是否在排除列表中
@@ -111,14 +207,51 @@ func shouldExclude(name string, excludeList []string) bool {
 
 // SkillInfo 表示一个 skill 的信息
 type SkillInfo struct {
-	Name string // skill 名称（目录名）
-	Path string // skill 完整路径
-	Desc string // 从 SKILL.md 提取的描述
+	Name    string        // skill 规范名称（优先取 frontmatter name，回退为目录名）
+	DirName string        // skill 所在目录名（或单 skill 仓库的仓库名）
+	Path    string        // skill 完整路径
+	Desc    string        // 从 SKILL.md 提取的描述
+	Compat  Compatibility // 目标工具兼容性声明
+	Plugin  string        // 所属插件（来自 .claude-plugin/marketplace.json，未声明时为空）
+
+	// 来源仓库 skillsync.yaml 中的声明
+	Default      bool     // 交互选择时默认选中
+	Hidden       bool     // 不在选择列表中显示
+	Experimental bool     // 实验性
+	Targets      []string // 推荐目标工具
+}
+
+// NameMismatch 判断 frontmatter name 是否与目录名不一致
+func (s SkillInfo) NameMismatch() bool {
+	return s.DirName != "" && s.Name != s.DirName
+}
+
+// LoadSkillInfo 读取 skill 目录并构建 SkillInfo
+// 入参: skillDir skill 目录路径，dirName 回退名称（目录名或仓库名）
+func LoadSkillInfo(skillDir, dirName string) SkillInfo {
+	info := SkillInfo{Name: dirName, DirName: dirName, Path: skillDir}
+	if meta, err := ReadMetadata(skillDir); err == nil {
+		info.Name = ResolveSkillName(meta, dirName)
+		info.Desc = meta.Description
+		info.Compat = ReadCompatibility(skillDir, meta)
+	}
+	return info
 }
 
 // ScanSkills scans directory recursively for valid skills (directories containing SKILL.md)
 // Supports nested structures common in monorepos (e.g., root/skills/skill-name/SKILL.md)
+// 仓库包含 skillsync.yaml 或 .claude-plugin/marketplace.json 时按清单发现 skill
 func ScanSkills(dir string) ([]SkillInfo, error) {
+	groups, err := ScanSkillGroups(dir)
+	if err != nil {
+		return nil, err
+	}
+	return FlattenGroups(groups), nil
+}
+
+// walkSkills 递归查找目录下的 SKILL.md，跳过隐藏目录
+// includeRoot 为 false 时忽略根目录自身的 SKILL.md（通常是模板）
+func walkSkills(dir string, includeRoot bool) ([]SkillInfo, error) {
 	var skills []SkillInfo
 
 	// Recursively scan for SKILL.md files
@@ -128,7 +261,7 @@ func ScanSkills(dir string) ([]SkillInfo, error) {
 		}
 
 		// Skip .git and other hidden directories
-		if info.IsDir() && strings.HasPrefix(info.Name(), ".") {
+		if info.IsDir() && path != dir && strings.HasPrefix(info.Name(), ".") {
 			return filepath.SkipDir
 		}
 
@@ -138,21 +271,12 @@ func ScanSkills(dir string) ([]SkillInfo, error) {
 			skillDir := filepath.Dir(path)
 
 			// Skip root directory SKILL.md (likely a template)
-			if skillDir == dir {
+			if skillDir == dir && !includeRoot {
 				return nil
 			}
 
-			// Extract skill name from directory
-			skillName := filepath.Base(skillDir)
-
-			// Extract description
-			desc := extractSkillDescription(path)
-
-			skills = append(skills, SkillInfo{
-				Name: skillName,
-				Path: skillDir,
-				Desc: desc,
-			})
+			// Resolve skill identity from frontmatter, falling back to directory name
+			skills = append(skills, LoadSkillInfo(skillDir, filepath.Base(skillDir)))
 		}
 
 		return nil
@@ -173,16 +297,7 @@ func extractSkillDescription(skillFile string) string {
 	if err != nil {
 		return ""
 	}
-
-	lines := strings.Split(string(content), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "description:") {
-			desc := strings.TrimPrefix(line, "description:")
-			return strings.TrimSpace(desc)
-		}
-	}
-	return ""
+	return ParseMetadata(content).Description
 }
 
 // ReadSkillDescription 读取指定 skill 目录中的描述信息
@@ -203,6 +318,59 @@ func ValidateSkillDir(dir string) error {
 	return nil
 }
 
+// FindSkillDir 在父目录中查找指定名称的 skill
+// 先按目录名精确匹配，再按 SKILL.md frontmatter name 匹配
+// 返回: skill 目录路径，未找到返回空字符串
+func FindSkillDir(parentDir, name string) string {
+	if dirs := FindSkillDirs(parentDir, name); len(dirs) > 0 {
+		return dirs[0]
+	}
+	return ""
+}
+
+// FindSkillDirs 在父目录中查找指定名称的 skill 的所有副本
+// 目录改名后重新安装等情况下，同一 skill 可能以不同目录名存在多份
+// 返回: 目录名精确匹配的副本在前，其后为 frontmatter name 匹配的副本
+func FindSkillDirs(parentDir, name string) []string {
+	if !IsSafeName(name) {
+		return nil
+	}
+
+	var dirs []string
+	direct := filepath.Join(parentDir, name)
+	if info, err := os.Stat(direct); err == nil && info.IsDir() {
+		dirs = append(dirs, direct)
+	}
+
+	entries, err := os.ReadDir(parentDir)
+	if err != nil {
+		return dirs
+	}
+	for _, entry := range entries {
+		if entry.Name() == name || !IsDirEntry(parentDir, entry) {
+			continue
+		}
+		entryPath := filepath.Join(parentDir, entry.Name())
+		if meta, err := ReadMetadata(entryPath); err == nil && meta.Name == name {
+			dirs = append(dirs, entryPath)
+		}
+	}
+	return dirs
+}
+
+// IsDirEntry 判断目录项是否为目录（跟随符号链接）
+// 以 --link 开发模式安装的 skill 是指向源目录的符号链接
+func IsDirEntry(parentDir string, entry os.DirEntry) bool {
+	if entry.IsDir() {
+		return true
+	}
+	if entry.Type()&os.ModeSymlink == 0 {
+		return false
+	}
+	info, err := os.Stat(filepath.Join(parentDir, entry.Name()))
+	return err == nil && info.IsDir()
+}
+
 // ExtractSkillName 从仓库 URL 或路径中提取 skill 名称
 func ExtractSkillName(source string) string {
 	// 移除 .git 后缀
@@ -0,0 +1,261 @@
+package skill
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeSkill 在 dir 下创建包含 SKILL.md 的 skill 目录
+func writeSkill(t *testing.T, dir, frontmatter string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\n" + frontmatter + "\n---\n\nBody\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanSkillsResolvesNames(t *testing.T) {
+	root := t.TempDir()
+	writeSkill(t, filepath.Join(root, "skills", "pdf-tools"), "name: pdf\ndescription: PDF helpers")
+	writeSkill(t, filepath.Join(root, "skills", "docx"), "description: no name")
+	writeSkill(t, filepath.Join(root, ".hidden", "secret"), "name: secret")
+
+	skills, err := ScanSkills(root)
+	if err != nil {
+		t.Fatalf("ScanSkills() error = %v", err)
+	}
+	names := map[string]string{}
+	for _, s := range skills {
+		names[s.Name] = s.DirName
+	}
+	want := map[string]string{"pdf": "pdf-tools", "docx": "docx"}
+	if len(names) != len(want) {
+		t.Fatalf("ScanSkills() names = %v, want %v", names, want)
+	}
+	for name, dir := range want {
+		if names[name] != dir {
+			t.Errorf("skill %q dir = %q, want %q", name, names[name], dir)
+		}
+	}
+}
+
+func TestScanSkillsDuplicateNames(t *testing.T) {
+	root := t.TempDir()
+	first := filepath.Join(root, "a", "review")
+	second := filepath.Join(root, "b", "code-review")
+	writeSkill(t, first, "name: review")
+	writeSkill(t, second, "name: review")
+
+	_, err := ScanSkills(root)
+	if err == nil {
+		t.Fatal("ScanSkills() error = nil, want duplicate name error")
+	}
+	for _, want := range []string{`"review"`, first, second} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+}
+
+func TestFindSkillDirs(t *testing.T) {
+	root := t.TempDir()
+	writeSkill(t, filepath.Join(root, "review"), "name: review")
+	writeSkill(t, filepath.Join(root, "code-review"), "name: review")
+	writeSkill(t, filepath.Join(root, "other"), "name: other")
+
+	got := FindSkillDirs(root, "review")
+	want := []string{filepath.Join(root, "review"), filepath.Join(root, "code-review")}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("FindSkillDirs() = %v, want %v", got, want)
+	}
+	if got := FindSkillDir(root, "review"); got != want[0] {
+		t.Errorf("FindSkillDir() = %q, want %q", got, want[0])
+	}
+	if got := FindSkillDirs(root, "../review"); got != nil {
+		t.Errorf("FindSkillDirs(unsafe) = %v, want nil", got)
+	}
+}
+
+// newLinkedSkill 创建包含各类符号链接的 skill：
+// docs/guide.md 指向 ../references/guide.md，refs 指向 references 目录
+func newLinkedSkill(t *testing.T) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "demo")
+	writeSkill(t, dir, "name: demo")
+	for _, sub := range []string{"references", "docs"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "references", "guide.md"), []byte("# Guide\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mustSymlink(t, "../references/guide.md", filepath.Join(dir, "docs", "guide.md"))
+	mustSymlink(t, "references", filepath.Join(dir, "refs"))
+	return dir
+}
+
+// mustSymlink 创建符号链接，失败时终止测试
+func mustSymlink(t *testing.T, target, link string) {
+	t.Helper()
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCopyDirSymlinkPolicies(t *testing.T) {
+	tests := []struct {
+		policy  SymlinkPolicy
+		wantErr string
+	}{
+		{SymlinkReject, "symlinks are not allowed"},
+		{SymlinkPreserve, ""},
+		{SymlinkDereference, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			src := newLinkedSkill(t)
+			dst := filepath.Join(t.TempDir(), "demo")
+			err := CopyDir(src, dst, CopyOptions{Symlinks: tt.policy})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("CopyDir() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CopyDir() error = %v", err)
+			}
+
+			for _, rel := range []string{"docs/guide.md", "refs"} {
+				info, err := os.Lstat(filepath.Join(dst, rel))
+				if err != nil {
+					t.Fatal(err)
+				}
+				isLink := info.Mode()&os.ModeSymlink != 0
+				if isLink != (tt.policy == SymlinkPreserve) {
+					t.Errorf("%s is symlink = %v, want %v", rel, isLink, tt.policy == SymlinkPreserve)
+				}
+			}
+			if tt.policy == SymlinkPreserve {
+				// 链接改写为相对路径，指向拷贝内的同一位置
+				if got, _ := os.Readlink(filepath.Join(dst, "docs", "guide.md")); got != filepath.Join("..", "references", "guide.md") {
+					t.Errorf("docs/guide.md links to %q, want ../references/guide.md", got)
+				}
+			}
+			for _, rel := range []string{"docs/guide.md", "refs/guide.md"} {
+				data, err := os.ReadFile(filepath.Join(dst, rel))
+				if err != nil || string(data) != "# Guide\n" {
+					t.Errorf("%s content = %q, %v, want the target's content", rel, data, err)
+				}
+			}
+		})
+	}
+}
+
+func TestCopyDirRefusesEscapingSymlink(t *testing.T) {
+	outside := filepath.Join(t.TempDir(), "secret.txt")
+	if err := os.WriteFile(outside, []byte("secret"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, policy := range SymlinkPolicies {
+		t.Run(string(policy), func(t *testing.T) {
+			src := filepath.Join(t.TempDir(), "demo")
+			writeSkill(t, src, "name: demo")
+			mustSymlink(t, outside, filepath.Join(src, "leak.txt"))
+
+			dst := filepath.Join(t.TempDir(), "demo")
+			if err := CopyDir(src, dst, CopyOptions{Symlinks: policy}); err == nil {
+				t.Fatal("CopyDir() copied a link that escapes the skill directory")
+			}
+			if _, err := os.Lstat(filepath.Join(dst, "leak.txt")); !os.IsNotExist(err) {
+				t.Error("escaping link was written to the destination")
+			}
+		})
+	}
+}
+
+func TestCopyDirSymlinkCycle(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "demo")
+	writeSkill(t, src, "name: demo")
+	if err := os.MkdirAll(filepath.Join(src, "a"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mustSymlink(t, "..", filepath.Join(src, "a", "loop"))
+
+	err := CopyDir(src, filepath.Join(t.TempDir(), "demo"), CopyOptions{Symlinks: SymlinkDereference})
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("CopyDir() error = %v, want a cycle error", err)
+	}
+}
+
+func TestCopyDirPreserveExcludedTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+	}{
+		{"excluded file", "references/guide.md"},
+		{"excluded parent directory", "references/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := newLinkedSkill(t)
+			rules, err := ParseIgnore([]byte(tt.pattern))
+			if err != nil {
+				t.Fatal(err)
+			}
+			opts := CopyOptions{Ignore: rules, Symlinks: SymlinkPreserve}
+			err = CopyDir(src, filepath.Join(t.TempDir(), "demo"), opts)
+			if err == nil || !strings.Contains(err.Error(), "excluded") {
+				t.Fatalf("CopyDir() error = %v, want the dangling link to be refused", err)
+			}
+		})
+	}
+}
+
+func TestCopyDirPreservesModesAndTimes(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "demo")
+	writeSkill(t, src, "name: demo")
+	script := filepath.Join(src, "scripts", "run.sh")
+	if err := os.MkdirAll(filepath.Dir(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(script, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(script, 0755); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, p := range []string{script, filepath.Dir(script)} {
+		if err := os.Chtimes(p, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dst := filepath.Join(t.TempDir(), "demo")
+	if err := CopyDir(src, dst, CopyOptions{}); err != nil {
+		t.Fatalf("CopyDir() error = %v", err)
+	}
+	for _, rel := range []string{"scripts/run.sh", "scripts"} {
+		info, err := os.Stat(filepath.Join(dst, rel))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0755 {
+			t.Errorf("%s mode = %v, want 0755", rel, info.Mode().Perm())
+		}
+		if !info.ModTime().Equal(modTime) {
+			t.Errorf("%s mtime = %v, want %v", rel, info.ModTime(), modTime)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Package skill 提供 skill 依赖声明与解析
+package skill
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlfonsSkills/SkillSync/internal/yaml"
+)
+
+// Dependency 表示 SKILL.md 中声明的一个依赖
+// frontmatter 写法（dependencies 可位于顶层或 metadata 下）：
+//
+//	metadata:
+//	  dependencies:
+//	    - source: AlfonsSkills/skills   # 省略时为当前 skill 所在仓库
+//	      name: changelog
+//	      ref: v1.2.0                   # 可选：分支或 tag
+//	    - AlfonsSkills/skills#git-conventions@main
+type Dependency struct {
+	Source string // 依赖所在仓库，空表示与声明方同仓库
+	Name   string // 依赖 skill 名称（frontmatter name）
+	Ref    string // 可选的分支或 tag 约束，空表示默认分支
+}
+
+// String 返回依赖的简写形式 source#name@ref
+func (d Dependency) String() string {
+	s := d.Name
+	if d.Source != "" {
+		s = d.Source + "#" + s
+	}
+	if d.Ref != "" {
+		s += "@" + d.Ref
+	}
+	return s
+}
+
+// ParseDependencyString 解析依赖简写
+// 支持格式: name、name@ref、source#name、source#name@ref
+func ParseDependencyString(s string) (Dependency, error) {
+	s = strings.TrimSpace(s)
+	var dep Dependency
+
+	rest := s
+	if idx := strings.LastIndex(s, "#"); idx >= 0 {
+		dep.Source = strings.TrimSpace(s[:idx])
+		rest = s[idx+1:]
+	}
+	if idx := strings.LastIndex(rest, "@"); idx >= 0 {
+		dep.Ref = strings.TrimSpace(rest[idx+1:])
+		rest = rest[:idx]
+	}
+	dep.Name = strings.TrimSpace(rest)
+
+	if !IsSafeName(dep.Name) {
+		return Dependency{}, fmt.Errorf("invalid dependency %q: missing or invalid skill name", s)
+	}
+	return dep, nil
+}
+
+// Dependencies 从元数据中解析依赖列表
+func (m *Metadata) Dependencies() ([]Dependency, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	raw, ok := m.Raw["dependencies"]
+	if !ok {
+		raw = yaml.Map(m.Raw, "metadata")["dependencies"]
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	items, ok := raw.([]any)
+	if !ok {
+		// 兼容逗号分隔的单个字符串
+		var deps []Dependency
+		for _, s := range yaml.StringSlice(raw) {
+			dep, err := ParseDependencyString(s)
+			if err != nil {
+				return nil, err
+			}
+			deps = append(deps, dep)
+		}
+		return deps, nil
+	}
+
+	var deps []Dependency
+	for _, item := range items {
+		switch v := item.(type) {
+		case string:
+			dep, err := ParseDependencyString(v)
+			if err != nil {
+				return nil, err
+			}
+			deps = append(deps, dep)
+		case map[string]any:
+			dep := Dependency{
+				Source: yaml.String(v, "source"),
+				Name:   yaml.String(v, "name"),
+				Ref:    yaml.String(v, "ref"),
+			}
+			if !IsSafeName(dep.Name) {
+				return nil, fmt.Errorf("invalid dependency: missing or invalid skill name")
+			}
+			deps = append(deps, dep)
+		default:
+			return nil, fmt.Errorf("invalid dependency entry: %v", item)
+		}
+	}
+	return deps, nil
+}
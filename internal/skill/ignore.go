@@ -0,0 +1,288 @@
+package skill
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IgnoreFile skill 目录或仓库根目录下的排除规则文件（gitignore 语法）
+const IgnoreFile = ".skillsyncignore"
+
+// IgnoreRule 单条 gitignore 风格规则
+type IgnoreRule struct {
+	Pattern string // 原始规则
+	negate  bool   // ! 开头，重新包含
+	dirOnly bool   // / 结尾，仅匹配目录
+	base    string // 规则文件所在目录到 skill 目录的相对路径，为空表示二者相同
+	re      *regexp.Regexp
+}
+
+// IgnoreRules 有序规则列表，后面的规则优先
+type IgnoreRules []IgnoreRule
+
+// ParseIgnoreRule 解析单条规则
+// 支持 # 注释、! 取反、/ 结尾仅匹配目录、/ 开头或中间含 / 时相对根目录匹配，以及 *、?、[...]、**
+func ParseIgnoreRule(line string) (IgnoreRule, bool, error) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return IgnoreRule{}, false, nil
+	}
+
+	rule := IgnoreRule{Pattern: line}
+	p := line
+	if strings.HasPrefix(p, "!") {
+		rule.negate = true
+		p = p[1:]
+	} else if strings.HasPrefix(p, `\!`) || strings.HasPrefix(p, `\#`) {
+		p = p[1:]
+	}
+	if strings.HasSuffix(p, "/") {
+		rule.dirOnly = true
+		p = strings.TrimRight(p, "/")
+	}
+	if p == "" {
+		return IgnoreRule{}, false, nil
+	}
+
+	// 关键步骤：含 / 的规则相对根目录匹配，否则匹配任意层级
+	anchored := strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+
+	expr, err := globToRegexp(p)
+	if err != nil {
+		return IgnoreRule{}, false, fmt.Errorf("invalid pattern %q: %w", line, err)
+	}
+	if !anchored {
+		expr = "(?:.*/)?" + expr
+	}
+	rule.re, err = regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return IgnoreRule{}, false, fmt.Errorf("invalid pattern %q: %w", line, err)
+	}
+	return rule, true, nil
+}
+
+// globToRegexp 将 glob 转换为正则表达式（不含首尾锚点）
+func globToRegexp(glob string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**") && i+2 == len(glob):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated character class")
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String(), nil
+}
+
+// ParseIgnore 解析 .skillsyncignore 内容
+func ParseIgnore(data []byte) (IgnoreRules, error) {
+	var rules IgnoreRules
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		rule, ok, err := ParseIgnoreRule(scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, scanner.Err()
+}
+
+// LoadIgnoreFile 读取目录下的 .skillsyncignore，不存在时返回空规则
+func LoadIgnoreFile(dir string) (IgnoreRules, error) {
+	data, err := os.ReadFile(filepath.Join(dir, IgnoreFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rules, err := ParseIgnore(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Join(dir, IgnoreFile), err)
+	}
+	return rules, nil
+}
+
+// Match 判断相对路径是否被排除（最后匹配的规则生效）
+// 入参: rel 相对 skill 目录、以 / 分隔的路径；每条规则按相对其规则文件所在目录的路径匹配
+func (r IgnoreRules) Match(rel string, isDir bool) bool {
+	excluded := false
+	for _, rule := range r {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		p := rel
+		if rule.base != "" {
+			p = rule.base + "/" + rel
+		}
+		if rule.re.MatchString(p) {
+			excluded = !rule.negate
+		}
+	}
+	return excluded
+}
+
+// withBase 返回以 base 为 skill 目录相对路径的规则副本
+func (r IgnoreRules) withBase(base string) IgnoreRules {
+	rules := make(IgnoreRules, len(r))
+	for i, rule := range r {
+		rule.base = base
+		rules[i] = rule
+	}
+	return rules
+}
+
+// WithPatterns 追加 --exclude / --include 规则
+// include 以取反规则追加在最后，可重新包含被 .skillsyncignore 排除的文件
+func (o CopyOptions) WithPatterns(excludes, includes []string) (CopyOptions, error) {
+	rules := append(IgnoreRules{}, o.Ignore...)
+	for _, p := range excludes {
+		rule, ok, err := ParseIgnoreRule(p)
+		if err != nil {
+			return o, err
+		}
+		if ok {
+			rules = append(rules, rule)
+		}
+	}
+	for _, p := range includes {
+		rule, ok, err := ParseIgnoreRule("!" + strings.TrimPrefix(p, "!"))
+		if err != nil {
+			return o, err
+		}
+		if ok {
+			rules = append(rules, rule)
+		}
+	}
+	o.Ignore = rules
+	return o, nil
+}
+
+// ForSkill 返回针对指定 skill 的拷贝选项
+// 依次加载仓库根目录与 skill 目录下的 .skillsyncignore，命令行规则仍然优先
+// 仓库根目录的规则按相对仓库根目录的路径匹配（如 /skills/foo/tmp）
+func (o CopyOptions) ForSkill(skillDir string) (CopyOptions, error) {
+	var fileRules IgnoreRules
+	if abs, err := filepath.Abs(skillDir); err == nil {
+		skillDir = abs
+	}
+	if root := FindRepoRoot(skillDir); root != "" && root != skillDir {
+		rules, err := LoadIgnoreFile(root)
+		if err != nil {
+			return o, err
+		}
+		base, err := filepath.Rel(root, skillDir)
+		if err != nil {
+			return o, err
+		}
+		fileRules = append(fileRules, rules.withBase(filepath.ToSlash(base))...)
+	}
+	rules, err := LoadIgnoreFile(skillDir)
+	if err != nil {
+		return o, err
+	}
+	fileRules = append(fileRules, rules...)
+	o.Ignore = append(fileRules, o.Ignore...)
+	return o, nil
+}
+
+// FindRepoRoot 向上查找包含 .git 的目录，找不到时返回空字符串
+func FindRepoRoot(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(abs, ".git")); err == nil {
+			return abs
+		}
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return ""
+		}
+		abs = parent
+	}
+}
+
+// ExcludeStats 排除统计
+type ExcludeStats struct {
+	Files int
+	Bytes int64
+}
+
+// CountExcluded 统计拷贝时会被排除的文件数与字节数
+// 默认排除的 .git 等元数据不计入统计
+func CountExcluded(src string, opts CopyOptions) (ExcludeStats, error) {
+	var stats ExcludeStats
+	err := filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == src {
+			return nil
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if opts.excludesByName(path.Base(rel), info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !opts.Ignore.Match(rel, info.IsDir()) {
+			return nil
+		}
+		if !info.IsDir() {
+			stats.Files++
+			stats.Bytes += info.Size()
+			return nil
+		}
+		// 被排除的目录整体计入
+		filepath.Walk(p, func(_ string, sub os.FileInfo, err error) error {
+			if err == nil && !sub.IsDir() {
+				stats.Files++
+				stats.Bytes += sub.Size()
+			}
+			return nil
+		})
+		return filepath.SkipDir
+	})
+	return stats, err
+}
@@ -0,0 +1,101 @@
+package skill
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIgnoreRulesMatch(t *testing.T) {
+	rules, err := ParseIgnore([]byte(`# comment
+*.log
+!keep.log
+build/
+/top.txt
+docs/*.md
+**/fixtures/**
+\#literal
+`))
+	if err != nil {
+		t.Fatalf("ParseIgnore() error = %v", err)
+	}
+
+	tests := []struct {
+		rel   string
+		isDir bool
+		want  bool
+	}{
+		{"debug.log", false, true},
+		{"nested/debug.log", false, true},
+		{"keep.log", false, false},
+		{"build", true, true},
+		{"build", false, false},
+		{"src/build", true, true},
+		{"top.txt", false, true},
+		{"sub/top.txt", false, false},
+		{"docs/guide.md", false, true},
+		{"docs/deep/guide.md", false, false},
+		{"a/fixtures/b/c.json", false, true},
+		{"#literal", false, true},
+		{"SKILL.md", false, false},
+	}
+	for _, tt := range tests {
+		if got := rules.Match(tt.rel, tt.isDir); got != tt.want {
+			t.Errorf("Match(%q, dir=%v) = %v, want %v", tt.rel, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestWithPatternsIncludeOverridesExclude(t *testing.T) {
+	opts, err := DefaultCopyOptions().WithPatterns([]string{"*.psd", "tests/"}, []string{"tests/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !opts.Excludes("art/logo.psd", false) {
+		t.Error("*.psd not excluded")
+	}
+	if opts.Excludes("tests", true) {
+		t.Error("--include did not re-include tests/")
+	}
+}
+
+func TestForSkillMatchesRootRulesFromRepoRoot(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	skillDir := filepath.Join(root, "skills", "foo")
+	writeSkill(t, skillDir, "name: foo")
+	writeFile := func(path, content string) {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(filepath.Join(root, IgnoreFile), "/skills/foo/tmp\nskills/*/fixtures/\n*.log\n/notes.md\n")
+	writeFile(filepath.Join(skillDir, IgnoreFile), "!keep.log\n")
+
+	opts, err := DefaultCopyOptions().ForSkill(skillDir)
+	if err != nil {
+		t.Fatalf("ForSkill() error = %v", err)
+	}
+
+	tests := []struct {
+		rel   string
+		isDir bool
+		want  bool
+	}{
+		{"tmp", true, true},
+		{"fixtures", true, true},
+		{"sub/fixtures", true, false},
+		{"debug.log", false, true},
+		{"keep.log", false, false},
+		// /notes.md 锚定在仓库根目录，不匹配 skill 内的同名文件
+		{"notes.md", false, false},
+		{"SKILL.md", false, false},
+	}
+	for _, tt := range tests {
+		if got := opts.Excludes(tt.rel, tt.isDir); got != tt.want {
+			t.Errorf("Excludes(%q, dir=%v) = %v, want %v", tt.rel, tt.isDir, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Package skill 提供来源仓库的 skillsync.yaml 清单解析
+package skill
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/AlfonsSkills/SkillSync/internal/yaml"
+)
+
+// RepoManifestFile 来源仓库根目录的 skill 清单
+// 声明仓库对外提供的 skill，避免模板、测试样例等被误扫描：
+//
+//	targets: [claude, codex]            # 推荐的目标工具
+//	skills:
+//	  - path: skills/code-review
+//	    default: true                   # 交互选择时默认选中
+//	  - path: skills/release
+//	    experimental: true
+//	    targets: [claude]
+//	  - path: skills/internal-helper
+//	    hidden: true                    # 不在选择列表中显示，仍可作为依赖安装
+//	  - skills/commit-style             # 简写：仅路径
+//	groups:
+//	  - name: essentials
+//	    description: Everyday review and commit skills
+//	    default: true
+//	    skills: [code-review, commit-style]   # skill 名称或路径
+const RepoManifestFile = "skillsync.yaml"
+
+// RepoManifest 解析后的来源仓库清单
+type RepoManifest struct {
+	Skills  []ManifestSkill
+	Groups  []ManifestGroup
+	Targets []string // 仓库级推荐目标工具
+}
+
+// ManifestSkill 清单中的单个 skill
+type ManifestSkill struct {
+	Path         string   // 相对仓库根目录的路径
+	Default      bool     // 交互选择时默认选中
+	Hidden       bool     // 不在选择列表中显示
+	Experimental bool     // 实验性，选择列表中标注
+	Targets      []string // 推荐目标工具
+}
+
+// ManifestGroup 清单中的 skill 分组
+type ManifestGroup struct {
+	Name        string
+	Description string
+	Default     bool     // 交互选择时默认选中整个分组
+	Skills      []string // skill 名称或路径
+}
+
+// ReadRepoManifest 读取目录下的 skillsync.yaml
+// 返回: 清单；文件不存在时返回 os.ErrNotExist
+func ReadRepoManifest(dir string) (*RepoManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, RepoManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	raw, err := yaml.ParseMap(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", RepoManifestFile, err)
+	}
+
+	m := &RepoManifest{Targets: lowerAll(yaml.StringSlice(raw["targets"]))}
+	items, _ := raw["skills"].([]any)
+	for i, item := range items {
+		switch v := item.(type) {
+		case string:
+			m.Skills = append(m.Skills, ManifestSkill{Path: strings.TrimSpace(v)})
+		case map[string]any:
+			s := ManifestSkill{
+				Path:         yaml.String(v, "path"),
+				Default:      yaml.Bool(v["default"]),
+				Hidden:       yaml.Bool(v["hidden"]),
+				Experimental: yaml.Bool(v["experimental"]),
+				Targets:      lowerAll(yaml.StringSlice(v["targets"])),
+			}
+			if s.Path == "" {
+				return nil, fmt.Errorf("invalid %s: skills[%d] is missing path", RepoManifestFile, i)
+			}
+			m.Skills = append(m.Skills, s)
+		default:
+			return nil, fmt.Errorf("invalid %s: skills[%d] must be a path or a mapping", RepoManifestFile, i)
+		}
+	}
+
+	groups, _ := raw["groups"].([]any)
+	for i, item := range groups {
+		v, ok := item.(map[string]any)
+		if !ok || yaml.String(v, "name") == "" {
+			return nil, fmt.Errorf("invalid %s: groups[%d] must be a mapping with a name", RepoManifestFile, i)
+		}
+		m.Groups = append(m.Groups, ManifestGroup{
+			Name:        yaml.String(v, "name"),
+			Description: yaml.String(v, "description"),
+			Default:     yaml.Bool(v["default"]),
+			Skills:      yaml.StringSlice(v["skills"]),
+		})
+	}
+	return m, nil
+}
+
+// scanRepoManifest 按清单构建 skill 分组
+// 未归入任何分组的 skill 放在末尾名称为空的分组中
+// 清单未列出 skills 时，使用各分组引用的路径
+func scanRepoManifest(root string, m *RepoManifest) ([]SkillGroup, error) {
+	entries := m.Skills
+	if len(entries) == 0 {
+		for _, g := range m.Groups {
+			for _, ref := range g.Skills {
+				entries = append(entries, ManifestSkill{Path: ref})
+			}
+		}
+	}
+
+	var skills []SkillInfo
+	byPath := make(map[string]int)
+	for _, e := range entries {
+		dir, err := joinRepoPath(root, e.Path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", RepoManifestFile, err)
+		}
+		if _, ok := byPath[dir]; ok {
+			continue
+		}
+		if err := ValidateSkillDir(dir); err != nil {
+			return nil, fmt.Errorf("%s: %w", RepoManifestFile, err)
+		}
+
+		info := LoadSkillInfo(dir, filepath.Base(dir))
+		info.Default = e.Default && !e.Experimental
+		info.Hidden = e.Hidden
+		info.Experimental = e.Experimental
+		info.Targets = e.Targets
+		if len(info.Targets) == 0 {
+			info.Targets = m.Targets
+		}
+		byPath[dir] = len(skills)
+		skills = append(skills, info)
+	}
+
+	// find 按名称或路径查找清单中的 skill
+	find := func(ref string) (int, bool) {
+		if dir, err := joinRepoPath(root, ref); err == nil {
+			if idx, ok := byPath[dir]; ok {
+				return idx, true
+			}
+		}
+		idx := slices.IndexFunc(skills, func(s SkillInfo) bool { return s.Name == ref })
+		return idx, idx >= 0
+	}
+
+	var groups []SkillGroup
+	grouped := make(map[int]bool)
+	for _, g := range m.Groups {
+		group := SkillGroup{Name: g.Name, Description: g.Description, Default: g.Default}
+		for _, ref := range g.Skills {
+			idx, ok := find(ref)
+			if !ok {
+				return nil, fmt.Errorf("%s: group %s references unknown skill %s", RepoManifestFile, g.Name, ref)
+			}
+			grouped[idx] = true
+			group.Skills = append(group.Skills, skills[idx])
+		}
+		groups = append(groups, group)
+	}
+
+	var rest SkillGroup
+	for i, s := range skills {
+		if !grouped[i] {
+			rest.Skills = append(rest.Skills, s)
+		}
+	}
+	if len(rest.Skills) > 0 {
+		groups = append(groups, rest)
+	}
+	return groups, nil
+}
@@ -0,0 +1,107 @@
+// Package skill 提供 SKILL.md 元数据解析
+package skill
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlfonsSkills/SkillSync/internal/yaml"
+)
+
+// Metadata 表示 SKILL.md frontmatter 中的元数据
+type Metadata struct {
+	Name        string         // frontmatter name 字段
+	Description string         // frontmatter description 字段
+	Raw         map[string]any // 完整 frontmatter，供扩展字段读取
+}
+
+// SplitFrontmatter 将 SKILL.md 内容拆分为 frontmatter 与正文
+// 返回: frontmatter 文本（不含 --- 分隔线）、正文、是否存在 frontmatter
+func SplitFrontmatter(content []byte) ([]byte, []byte, bool) {
+	content = bytes.TrimPrefix(content, []byte("\ufeff"))
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	if !strings.HasPrefix(text, "---\n") {
+		return nil, content, false
+	}
+
+	// 关键步骤：在 rest 前补换行，兼容 frontmatter 为空的情况
+	rest := "\n" + text[len("---\n"):]
+	end := strings.Index(rest, "\n---")
+	if end < 0 {
+		return nil, content, false
+	}
+
+	body := rest[end+len("\n---"):]
+	// 跳过结束分隔线所在行的剩余部分
+	if idx := strings.IndexByte(body, '\n'); idx >= 0 {
+		body = body[idx+1:]
+	} else {
+		body = ""
+	}
+	return []byte(rest[1:max(end, 1)]), []byte(body), true
+}
+
+// ParseMetadata 解析 SKILL.md 内容中的 frontmatter
+// 无 frontmatter 或解析失败时，回退为逐行查找 name/description 字段
+func ParseMetadata(content []byte) *Metadata {
+	meta := &Metadata{Raw: map[string]any{}}
+
+	if front, _, ok := SplitFrontmatter(content); ok {
+		if raw, err := yaml.ParseMap(front); err == nil {
+			meta.Raw = raw
+			meta.Name = yaml.String(raw, "name")
+			meta.Description = yaml.String(raw, "description")
+			return meta
+		}
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if meta.Name == "" && strings.HasPrefix(line, "name:") {
+			meta.Name = trimQuotes(strings.TrimSpace(strings.TrimPrefix(line, "name:")))
+		}
+		if meta.Description == "" && strings.HasPrefix(line, "description:") {
+			meta.Description = strings.TrimSpace(strings.TrimPrefix(line, "description:"))
+		}
+	}
+	return meta
+}
+
+// ReadMetadata 读取 skill 目录中 SKILL.md 的元数据
+// 入参: skillDir skill 目录路径
+// 返回: 元数据；SKILL.md 不存在或读取失败时返回 error
+func ReadMetadata(skillDir string) (*Metadata, error) {
+	content, err := os.ReadFile(filepath.Join(skillDir, "SKILL.md"))
+	if err != nil {
+		return nil, err
+	}
+	return ParseMetadata(content), nil
+}
+
+// ResolveSkillName 解析 skill 的规范名称
+// 优先使用 frontmatter name，缺失或不安全时回退为 fallback（通常为目录名）
+func ResolveSkillName(meta *Metadata, fallback string) string {
+	if meta != nil && IsSafeName(meta.Name) {
+		return meta.Name
+	}
+	return fallback
+}
+
+// IsSafeName 检查名称能否安全用作安装目录名
+// 拒绝空值、路径分隔符以及 . / .. 等会导致路径逃逸的名称
+func IsSafeName(name string) bool {
+	if name == "" || name == "." || name == ".." {
+		return false
+	}
+	return !strings.ContainsAny(name, `/\`)
+}
+
+// trimQuotes 去除首尾成对的引号
+func trimQuotes(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
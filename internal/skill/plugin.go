@@ -0,0 +1,279 @@
+// Package skill 提供 Claude Code 插件市场清单的读写与基于清单的 skill 发现
+package skill
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Claude Code 插件相关路径
+const (
+	PluginManifestDir  = ".claude-plugin"
+	MarketplaceFile    = "marketplace.json"
+	PluginManifestFile = "plugin.json"
+	PluginSkillsDir    = "skills"
+)
+
+// MarketplaceOwner 市场维护者
+type MarketplaceOwner struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+}
+
+// MarketplaceMetadata 市场元数据
+type MarketplaceMetadata struct {
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version,omitempty"`
+	PluginRoot  string `json:"pluginRoot,omitempty"`
+}
+
+// MarketplacePlugin 市场中的单个插件条目
+// Source 为相对市场根目录的路径（./plugins/x）或远程来源对象，这里只处理字符串形式
+type MarketplacePlugin struct {
+	Name        string   `json:"name"`
+	Source      any      `json:"source"`
+	Description string   `json:"description,omitempty"`
+	Version     string   `json:"version,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+	Strict      *bool    `json:"strict,omitempty"`
+	Skills      []string `json:"skills,omitempty"` // 插件内 skill 目录（相对插件根目录）
+}
+
+// Marketplace .claude-plugin/marketplace.json
+type Marketplace struct {
+	Name     string               `json:"name"`
+	Owner    MarketplaceOwner     `json:"owner"`
+	Metadata *MarketplaceMetadata `json:"metadata,omitempty"`
+	Plugins  []MarketplacePlugin  `json:"plugins"`
+}
+
+// PluginManifest 插件目录下的 .claude-plugin/plugin.json
+type PluginManifest struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Version     string            `json:"version,omitempty"`
+	Author      *MarketplaceOwner `json:"author,omitempty"`
+	Keywords    []string          `json:"keywords,omitempty"`
+}
+
+// ReadMarketplace 读取目录下的 .claude-plugin/marketplace.json
+// 返回: 清单；文件不存在时返回 os.ErrNotExist
+func ReadMarketplace(root string) (*Marketplace, error) {
+	data, err := os.ReadFile(filepath.Join(root, PluginManifestDir, MarketplaceFile))
+	if err != nil {
+		return nil, err
+	}
+	var m Marketplace
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", MarketplaceFile, err)
+	}
+	return &m, nil
+}
+
+// SkillGroup 按插件或清单分组的 skill
+// 没有清单时只有一个 Name 为空的分组
+type SkillGroup struct {
+	Name        string // 插件或分组名称
+	Description string // 插件或分组描述
+	Default     bool   // 交互选择时默认选中整个分组
+	Skills      []SkillInfo
+}
+
+// ScanSkillGroups 扫描目录中的 skill 并分组
+// 查找顺序：skillsync.yaml 清单、.claude-plugin/marketplace.json 中的本地插件；
+// 清单不存在或未发现任何 skill 时回退为递归查找 SKILL.md
+// 多个 skill 解析为同一名称时返回错误，避免安装时互相覆盖
+func ScanSkillGroups(dir string) ([]SkillGroup, error) {
+	groups, err := scanSkillGroups(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDuplicateNames(FlattenGroups(groups)); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// checkDuplicateNames 检查是否有多个 skill 解析为同一名称
+func checkDuplicateNames(skills []SkillInfo) error {
+	paths := make(map[string]string, len(skills))
+	for _, s := range skills {
+		if first, ok := paths[s.Name]; ok {
+			return fmt.Errorf("duplicate skill name %q: %s and %s", s.Name, first, s.Path)
+		}
+		paths[s.Name] = s.Path
+	}
+	return nil
+}
+
+// scanSkillGroups 按清单或目录结构发现 skill
+func scanSkillGroups(dir string) ([]SkillGroup, error) {
+	manifest, err := ReadRepoManifest(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if manifest != nil {
+		groups, err := scanRepoManifest(dir, manifest)
+		if err != nil {
+			return nil, err
+		}
+		if len(groups) > 0 {
+			return groups, nil
+		}
+	}
+
+	market, err := ReadMarketplace(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if market != nil {
+		groups, err := scanMarketplace(dir, market)
+		if err != nil {
+			return nil, err
+		}
+		if len(groups) > 0 {
+			return groups, nil
+		}
+	}
+
+	skills, err := walkSkills(dir, false)
+	if err != nil || len(skills) == 0 {
+		return nil, err
+	}
+	return []SkillGroup{{Skills: skills}}, nil
+}
+
+// FlattenGroups 合并各分组的 skill，按路径去重并保持顺序
+func FlattenGroups(groups []SkillGroup) []SkillInfo {
+	var skills []SkillInfo
+	seen := make(map[string]bool)
+	for _, g := range groups {
+		for _, s := range g.Skills {
+			if !seen[s.Path] {
+				seen[s.Path] = true
+				skills = append(skills, s)
+			}
+		}
+	}
+	return skills
+}
+
+// scanMarketplace 按清单中的插件收集 skill
+// 远程来源（github、url 等对象形式）的插件无法在本地解析，直接跳过
+// 同一 skill 目录出现在多个插件中时只归入第一个插件
+func scanMarketplace(root string, market *Marketplace) ([]SkillGroup, error) {
+	var groups []SkillGroup
+	seen := make(map[string]bool)
+	for _, p := range market.Plugins {
+		source, ok := p.Source.(string)
+		if !ok {
+			continue
+		}
+		pluginDir, err := joinRepoPath(root, pluginSourcePath(market, source))
+		if err != nil {
+			return nil, fmt.Errorf("plugin %s: %w", p.Name, err)
+		}
+
+		desc := p.Description
+		if desc == "" {
+			if manifest, err := readPluginManifest(pluginDir); err == nil {
+				desc = manifest.Description
+			}
+		}
+
+		// 关键步骤：未声明 skills 时使用插件默认的 skills/ 目录
+		skillPaths := p.Skills
+		if len(skillPaths) == 0 {
+			skillPaths = []string{PluginSkillsDir}
+		}
+
+		group := SkillGroup{Name: p.Name, Description: desc}
+		for _, sp := range skillPaths {
+			skillsDir, err := joinRepoPath(pluginDir, sp)
+			if err != nil {
+				return nil, fmt.Errorf("plugin %s: %w", p.Name, err)
+			}
+			if info, err := os.Stat(skillsDir); err != nil || !info.IsDir() {
+				continue
+			}
+			found, err := walkSkills(skillsDir, true)
+			if err != nil {
+				return nil, err
+			}
+			for _, s := range found {
+				if seen[s.Path] {
+					continue
+				}
+				seen[s.Path] = true
+				s.Plugin = p.Name
+				group.Skills = append(group.Skills, s)
+			}
+		}
+		if len(group.Skills) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+// pluginSourcePath 返回插件来源相对市场根目录的路径
+// metadata.pluginRoot 仅作用于不以 ./ 开头的简写来源
+func pluginSourcePath(market *Marketplace, source string) string {
+	if market.Metadata != nil && market.Metadata.PluginRoot != "" &&
+		!strings.HasPrefix(source, "./") && !strings.HasPrefix(source, "../") {
+		return filepath.Join(market.Metadata.PluginRoot, source)
+	}
+	return source
+}
+
+// joinRepoPath 拼接清单中的相对路径，拒绝指向 base 之外的路径
+func joinRepoPath(base, rel string) (string, error) {
+	if filepath.IsAbs(rel) {
+		return "", fmt.Errorf("absolute path not allowed: %s", rel)
+	}
+	path := filepath.Join(base, filepath.FromSlash(rel))
+	if !isWithin(base, path) {
+		return "", fmt.Errorf("path escapes repository: %s", rel)
+	}
+	return path, nil
+}
+
+// readPluginManifest 读取插件目录下的 .claude-plugin/plugin.json
+func readPluginManifest(pluginDir string) (*PluginManifest, error) {
+	data, err := os.ReadFile(filepath.Join(pluginDir, PluginManifestDir, PluginManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var p PluginManifest
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", PluginManifestFile, err)
+	}
+	return &p, nil
+}
+
+// writeJSON 以缩进格式写入 JSON 文件
+func writeJSON(file string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(data, '\n'), 0644)
+}
+
+// WriteMarketplace 写入 <root>/.claude-plugin/marketplace.json
+func WriteMarketplace(root string, m *Marketplace) error {
+	return writeJSON(filepath.Join(root, PluginManifestDir, MarketplaceFile), m)
+}
+
+// WritePluginManifest 写入 <pluginDir>/.claude-plugin/plugin.json
+func WritePluginManifest(pluginDir string, p *PluginManifest) error {
+	return writeJSON(filepath.Join(pluginDir, PluginManifestDir, PluginManifestFile), p)
+}
@@ -0,0 +1,52 @@
+// Package skill 提供已安装 skill 的来源记录
+package skill
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/AlfonsSkills/SkillSync/internal/verify"
+)
+
+// ProvenanceFile 安装时写入 skill 目录的来源记录文件
+const ProvenanceFile = ".skillsync-provenance.json"
+
+// Provenance 记录 skill 的安装来源与签名校验结果
+type Provenance struct {
+	Name         string        `json:"name"`
+	Source       string        `json:"source"`
+	Ref          string        `json:"ref,omitempty"`
+	Commit       string        `json:"commit,omitempty"`
+	InstalledAt  time.Time     `json:"installed_at"`
+	VerifyPolicy string        `json:"verify_policy"`
+	Verification verify.Result `json:"verification"`
+}
+
+// WriteProvenance 将来源记录写入已安装的 skill 目录
+func WriteProvenance(skillDir string, p Provenance) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, ProvenanceFile), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write provenance: %w", err)
+	}
+	return nil
+}
+
+// ReadProvenance 读取已安装 skill 的来源记录
+// 返回: 来源记录；文件不存在或格式错误时返回 error
+func ReadProvenance(skillDir string) (*Provenance, error) {
+	data, err := os.ReadFile(filepath.Join(skillDir, ProvenanceFile))
+	if err != nil {
+		return nil, err
+	}
+	var p Provenance
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid provenance: %w", err)
+	}
+	return &p, nil
+}
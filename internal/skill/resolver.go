@@ -0,0 +1,262 @@
+// Package skill 提供 skill 依赖图解析
+package skill
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SourceLoader 定义依赖解析所需的仓库加载能力
+// 由调用方实现（通常基于 git.Fetcher），避免 skill 包直接依赖 git
+type SourceLoader interface {
+	// Key 返回仓库的规范化标识，用于判定两个 source 是否为同一仓库
+	Key(source string) string
+
+	// Load 拉取指定 ref 的仓库并返回本地目录，ref 为空表示默认分支
+	Load(source, ref string) (string, error)
+}
+
+// RootSkill 表示用户直接选择安装的 skill 及其来源
+type RootSkill struct {
+	Info   SkillInfo
+	Source string // 仓库输入（与 install 参数一致）
+	Ref    string // 分支或 tag（Tree URL 时为其分支）
+}
+
+// ResolvedDependency 表示解析得到的额外依赖 skill
+type ResolvedDependency struct {
+	Info       SkillInfo
+	Source     string // 依赖所在仓库
+	Ref        string // 实际使用的分支或 tag
+	RequiredBy string // 首个声明该依赖的 skill 名称
+}
+
+// depNode 表示依赖图中的一个节点
+type depNode struct {
+	info       SkillInfo
+	source     string
+	ref        string
+	refBy      string // 声明 ref 约束的 skill
+	requiredBy string
+	root       bool
+}
+
+// refPin 解析过程中确定的依赖 ref 约束
+type refPin struct {
+	ref        string
+	requiredBy string
+}
+
+// errRefPinned 依赖切换到新的 ref，需要重新解析
+var errRefPinned = errors.New("dependency ref pinned")
+
+// dependencyResolver 保存一次解析的状态
+type dependencyResolver struct {
+	loader   SourceLoader
+	pins     map[string]refPin // 未约束依赖切换到的 ref（跨重新解析保留）
+	nodes    map[string]*depNode
+	visiting []string // 当前 DFS 路径，用于检测循环
+	done     map[string]bool
+	order    []string // 后序遍历结果：依赖在前
+}
+
+// ResolveDependencies 解析所选 skill 的传递依赖
+// 入参: roots 用户选择的 skill，loader 仓库加载器
+// 返回: 需要额外安装的依赖（按安装顺序，依赖在前）
+// 错误: 循环依赖、ref 约束冲突或依赖无法找到
+func ResolveDependencies(roots []RootSkill, loader SourceLoader) ([]ResolvedDependency, error) {
+	// 关键步骤：未约束的依赖遇到 ref 约束时记录约束并重新解析，
+	// 丢弃按旧 ref 解析得到的传递依赖；约束只增不减，重新解析次数有限
+	pins := make(map[string]refPin)
+	for {
+		deps, err := resolveOnce(roots, loader, pins)
+		if !errors.Is(err, errRefPinned) {
+			return deps, err
+		}
+	}
+}
+
+// resolveOnce 按已记录的 ref 约束执行一次完整解析
+func resolveOnce(roots []RootSkill, loader SourceLoader, pins map[string]refPin) ([]ResolvedDependency, error) {
+	r := &dependencyResolver{
+		loader: loader,
+		pins:   pins,
+		nodes:  make(map[string]*depNode),
+		done:   make(map[string]bool),
+	}
+
+	// 关键步骤：先登记所有根节点，依赖指向已选 skill 时不重复安装
+	for _, root := range roots {
+		key := r.nodeKey(root.Source, root.Info.Name)
+		r.nodes[key] = &depNode{info: root.Info, source: root.Source, ref: root.Ref, root: true}
+	}
+
+	for _, root := range roots {
+		if err := r.visit(r.nodeKey(root.Source, root.Info.Name)); err != nil {
+			return nil, err
+		}
+	}
+
+	// 不同来源的同名 skill 会安装到同一目录，视为冲突
+	names := make(map[string]string)
+	for key, node := range r.nodes {
+		if other, ok := names[node.info.Name]; ok && other != key {
+			return nil, fmt.Errorf("conflicting skills named %s from %s and %s", node.info.Name,
+				strings.SplitN(other, "#", 2)[0], strings.SplitN(key, "#", 2)[0])
+		}
+		names[node.info.Name] = key
+	}
+
+	var result []ResolvedDependency
+	for _, key := range r.order {
+		node := r.nodes[key]
+		if node.root {
+			continue
+		}
+		result = append(result, ResolvedDependency{
+			Info:       node.info,
+			Source:     node.source,
+			Ref:        node.ref,
+			RequiredBy: node.requiredBy,
+		})
+	}
+	return result, nil
+}
+
+// nodeKey 生成依赖图节点标识：仓库 key + skill 名称
+func (r *dependencyResolver) nodeKey(source, name string) string {
+	return r.loader.Key(source) + "#" + name
+}
+
+// visit 深度优先遍历节点的依赖
+func (r *dependencyResolver) visit(key string) error {
+	if r.done[key] {
+		return nil
+	}
+	for i, k := range r.visiting {
+		if k == key {
+			return fmt.Errorf("dependency cycle detected: %s", r.cyclePath(r.visiting[i:], key))
+		}
+	}
+
+	r.visiting = append(r.visiting, key)
+	defer func() { r.visiting = r.visiting[:len(r.visiting)-1] }()
+
+	node := r.nodes[key]
+	meta, err := ReadMetadata(node.info.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", node.info.Name, err)
+	}
+	deps, err := meta.Dependencies()
+	if err != nil {
+		return fmt.Errorf("skill %s: %w", node.info.Name, err)
+	}
+
+	for _, dep := range deps {
+		// 同仓库依赖未指定 ref 时沿用声明方的 ref
+		source := dep.Source
+		if source == "" {
+			source = node.source
+			if dep.Ref == "" {
+				dep.Ref = node.ref
+			}
+		}
+		depKey := r.nodeKey(source, dep.Name)
+
+		if existing, ok := r.nodes[depKey]; ok {
+			if err := r.reconcileRef(depKey, existing, dep, node.info.Name); err != nil {
+				return err
+			}
+		} else {
+			refBy := node.info.Name
+			if pin, ok := r.pins[depKey]; ok {
+				if dep.Ref != "" && dep.Ref != pin.ref {
+					return fmt.Errorf("conflicting version constraints for %s: %s (required by %s) vs %s (required by %s)",
+						dep.Name, pin.ref, pin.requiredBy, dep.Ref, node.info.Name)
+				}
+				dep.Ref, refBy = pin.ref, pin.requiredBy
+			}
+			info, err := r.find(source, dep.Ref, dep.Name)
+			if err != nil {
+				return fmt.Errorf("skill %s requires %s: %w", node.info.Name, dep, err)
+			}
+			r.nodes[depKey] = &depNode{info: info, source: source, ref: dep.Ref, refBy: refBy, requiredBy: node.info.Name}
+		}
+
+		if err := r.visit(depKey); err != nil {
+			return err
+		}
+	}
+
+	r.done[key] = true
+	if !slices.Contains(r.order, key) {
+		r.order = append(r.order, key)
+	}
+	return nil
+}
+
+// reconcileRef 合并同一依赖的 ref 约束
+// 两个不同的非空 ref 视为冲突；已有节点未约束时记录新约束并要求重新解析
+func (r *dependencyResolver) reconcileRef(key string, existing *depNode, dep Dependency, requiredBy string) error {
+	if dep.Ref == "" || dep.Ref == existing.ref {
+		return nil
+	}
+	if existing.ref != "" || existing.root {
+		current := existing.ref
+		if current == "" {
+			current = "default branch"
+		}
+		owner := existing.refBy
+		if existing.root {
+			owner = "selected skills"
+		}
+		return fmt.Errorf("conflicting version constraints for %s: %s (required by %s) vs %s (required by %s)",
+			dep.Name, current, owner, dep.Ref, requiredBy)
+	}
+
+	// 先确认新 ref 中存在该 skill，避免重新解析后才报错
+	if _, err := r.find(existing.source, dep.Ref, dep.Name); err != nil {
+		return fmt.Errorf("skill %s requires %s: %w", requiredBy, dep, err)
+	}
+	r.pins[key] = refPin{ref: dep.Ref, requiredBy: requiredBy}
+	return errRefPinned
+}
+
+// find 在指定仓库中查找 skill
+func (r *dependencyResolver) find(source, ref, name string) (SkillInfo, error) {
+	dir, err := r.loader.Load(source, ref)
+	if err != nil {
+		return SkillInfo{}, err
+	}
+
+	skills, err := ScanSkills(dir)
+	if err != nil {
+		return SkillInfo{}, err
+	}
+	for _, s := range skills {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+
+	// 单 skill 仓库：根目录即 skill
+	if len(skills) == 0 && ValidateSkillDir(dir) == nil {
+		info := LoadSkillInfo(dir, ExtractSkillName(source))
+		if info.Name == name {
+			return info, nil
+		}
+	}
+	return SkillInfo{}, fmt.Errorf("skill %q not found in %s", name, source)
+}
+
+// cyclePath 将循环路径格式化为 a -> b -> a
+func (r *dependencyResolver) cyclePath(path []string, back string) string {
+	names := make([]string, 0, len(path)+1)
+	for _, k := range path {
+		names = append(names, r.nodes[k].info.Name)
+	}
+	names = append(names, r.nodes[back].info.Name)
+	return strings.Join(names, " -> ")
+}
@@ -0,0 +1,210 @@
+package skill
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeLoader 以临时目录模拟仓库，key 为 source@ref
+type fakeLoader struct {
+	root string
+}
+
+func (l fakeLoader) Key(source string) string { return source }
+
+func (l fakeLoader) Load(source, ref string) (string, error) {
+	if ref == "" {
+		ref = "main"
+	}
+	return filepath.Join(l.root, source, ref), nil
+}
+
+// repoSkill 描述测试仓库中的一个 skill
+type repoSkill struct {
+	source, ref, name string
+	deps              []string
+}
+
+// newFakeRepos 创建测试仓库并返回加载器
+func newFakeRepos(t *testing.T, skills []repoSkill) fakeLoader {
+	t.Helper()
+	l := fakeLoader{root: t.TempDir()}
+	for _, s := range skills {
+		dir, _ := l.Load(s.source, s.ref)
+		frontmatter := "name: " + s.name
+		if len(s.deps) > 0 {
+			frontmatter += "\ndependencies: [" + strings.Join(s.deps, ", ") + "]"
+		}
+		writeSkill(t, filepath.Join(dir, s.name), frontmatter)
+	}
+	return l
+}
+
+// rootSkill 从测试仓库加载用户选择的 skill
+func rootSkill(t *testing.T, l fakeLoader, source, name string) RootSkill {
+	t.Helper()
+	dir, _ := l.Load(source, "")
+	return RootSkill{Info: LoadSkillInfo(filepath.Join(dir, name), name), Source: source}
+}
+
+// depSummary 将解析结果格式化为 name@ref 列表
+func depSummary(deps []ResolvedDependency) []string {
+	var result []string
+	for _, d := range deps {
+		ref := d.Ref
+		if ref == "" {
+			ref = "main"
+		}
+		result = append(result, fmt.Sprintf("%s@%s", d.Info.Name, ref))
+	}
+	return result
+}
+
+func TestResolveDependencies(t *testing.T) {
+	tests := []struct {
+		name    string
+		skills  []repoSkill
+		roots   []string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "no dependencies",
+			skills: []repoSkill{
+				{source: "repo", name: "a"},
+			},
+			roots: []string{"a"},
+		},
+		{
+			name: "chain in dependency order",
+			skills: []repoSkill{
+				{source: "repo", name: "a", deps: []string{"b"}},
+				{source: "repo", name: "b", deps: []string{"c"}},
+				{source: "repo", name: "c"},
+			},
+			roots: []string{"a"},
+			want:  []string{"c@main", "b@main"},
+		},
+		{
+			name: "diamond installs shared dependency once",
+			skills: []repoSkill{
+				{source: "repo", name: "a", deps: []string{"b", "c"}},
+				{source: "repo", name: "b", deps: []string{"d"}},
+				{source: "repo", name: "c", deps: []string{"d"}},
+				{source: "repo", name: "d"},
+			},
+			roots: []string{"a"},
+			want:  []string{"d@main", "b@main", "c@main"},
+		},
+		{
+			name: "dependency on a selected skill is not repeated",
+			skills: []repoSkill{
+				{source: "repo", name: "a", deps: []string{"b"}},
+				{source: "repo", name: "b"},
+			},
+			roots: []string{"a", "b"},
+		},
+		{
+			name: "cross-repository dependency",
+			skills: []repoSkill{
+				{source: "repo", name: "a", deps: []string{"other#x@v1"}},
+				{source: "other", ref: "v1", name: "x"},
+			},
+			roots: []string{"a"},
+			want:  []string{"x@v1"},
+		},
+		{
+			name: "cycle",
+			skills: []repoSkill{
+				{source: "repo", name: "a", deps: []string{"b"}},
+				{source: "repo", name: "b", deps: []string{"c"}},
+				{source: "repo", name: "c", deps: []string{"a"}},
+			},
+			roots:   []string{"a"},
+			wantErr: "dependency cycle detected: a -> b -> c -> a",
+		},
+		{
+			name: "self dependency",
+			skills: []repoSkill{
+				{source: "repo", name: "a", deps: []string{"a"}},
+			},
+			roots:   []string{"a"},
+			wantErr: "dependency cycle detected: a -> a",
+		},
+		{
+			name: "conflicting refs",
+			skills: []repoSkill{
+				{source: "repo", name: "a", deps: []string{"b", "c"}},
+				{source: "repo", name: "b", deps: []string{"other#x@v1"}},
+				{source: "repo", name: "c", deps: []string{"other#x@v2"}},
+				{source: "other", ref: "v1", name: "x"},
+				{source: "other", ref: "v2", name: "x"},
+			},
+			roots:   []string{"a"},
+			wantErr: "conflicting version constraints for x: v1 (required by b) vs v2 (required by c)",
+		},
+		{
+			name: "conflict with pinned ref after re-resolution",
+			skills: []repoSkill{
+				{source: "repo", name: "a", deps: []string{"b", "c", "d"}},
+				{source: "repo", name: "b", deps: []string{"other#x"}},
+				{source: "repo", name: "c", deps: []string{"other#x@v1"}},
+				{source: "repo", name: "d", deps: []string{"other#x@v2"}},
+				{source: "other", name: "x"},
+				{source: "other", ref: "v1", name: "x"},
+				{source: "other", ref: "v2", name: "x"},
+			},
+			roots:   []string{"a"},
+			wantErr: "conflicting version constraints for x: v1 (required by c) vs v2 (required by d)",
+		},
+		{
+			name: "ref constraint drops dependencies of the old ref",
+			skills: []repoSkill{
+				{source: "repo", name: "a", deps: []string{"b", "c"}},
+				{source: "repo", name: "b", deps: []string{"other#x"}},
+				{source: "repo", name: "c", deps: []string{"other#x@v2"}},
+				{source: "other", name: "x", deps: []string{"old"}},
+				{source: "other", name: "old"},
+				{source: "other", ref: "v2", name: "x", deps: []string{"new"}},
+				{source: "other", ref: "v2", name: "new"},
+			},
+			roots: []string{"a"},
+			want:  []string{"new@v2", "x@v2", "b@main", "c@main"},
+		},
+		{
+			name: "missing dependency",
+			skills: []repoSkill{
+				{source: "repo", name: "a", deps: []string{"missing"}},
+			},
+			roots:   []string{"a"},
+			wantErr: `skill "missing" not found in repo`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newFakeRepos(t, tt.skills)
+			var roots []RootSkill
+			for _, name := range tt.roots {
+				roots = append(roots, rootSkill(t, l, "repo", name))
+			}
+
+			deps, err := ResolveDependencies(roots, l)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ResolveDependencies() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveDependencies() error = %v", err)
+			}
+			if got := depSummary(deps); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveDependencies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+// Package skill 提供新 skill 脚手架生成
+package skill
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxNameLength skill 名称最大长度（Agent Skills 规范）
+const MaxNameLength = 64
+
+// skillNameRegex 合法名称：小写字母、数字与单个连字符，首尾不能为连字符
+var skillNameRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ValidateName 按 Agent Skills 规范校验 skill 名称
+// 规则：1-64 个字符，仅含小写字母、数字和连字符，不能以连字符开头或结尾，不能包含连续连字符
+func ValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("skill name is required")
+	}
+	if len(name) > MaxNameLength {
+		return fmt.Errorf("skill name %q exceeds %d characters", name, MaxNameLength)
+	}
+	if !skillNameRegex.MatchString(name) {
+		return fmt.Errorf("skill name %q must contain only lowercase letters, digits and single hyphens, and must not start or end with a hyphen", name)
+	}
+	return nil
+}
+
+// ScaffoldOptions 生成新 skill 的选项
+type ScaffoldOptions struct {
+	Name        string   // skill 名称
+	Description string   // skill 描述
+	Dir         string   // 生成目录（skill 目录本身）
+	TemplateDir string   // 用户模板目录，为空时使用内置模板
+	Template    string   // 内置模板名称
+	Folders     []string // 额外创建的可选目录（scripts/references/assets）
+}
+
+// OptionalFolders 规范中定义的可选子目录
+var OptionalFolders = []string{"scripts", "references", "assets"}
+
+// builtinTemplate 内置模板：相对路径 -> 内容
+type builtinTemplate struct {
+	Desc  string
+	Files map[string]string
+}
+
+// builtinTemplates 内置模板集合，内容中的 {{name}}/{{title}}/{{description}} 会被替换
+var builtinTemplates = map[string]builtinTemplate{
+	"basic": {
+		Desc: "SKILL.md with frontmatter and instruction outline",
+		Files: map[string]string{
+			"SKILL.md": `---
+name: {{name}}
+description: {{description}}
+---
+
+# {{title}}
+
+## When to use
+
+Describe the situations in which this skill should be applied.
+
+## Instructions
+
+1. First step
+2. Second step
+`,
+		},
+	},
+	"script": {
+		Desc: "SKILL.md plus a helper script in scripts/",
+		Files: map[string]string{
+			"SKILL.md": `---
+name: {{name}}
+description: {{description}}
+---
+
+# {{title}}
+
+## When to use
+
+Describe the situations in which this skill should be applied.
+
+## Instructions
+
+1. Run ` + "`scripts/run.sh`" + ` from the skill directory
+2. Review the output and continue
+`,
+			"scripts/run.sh": `#!/usr/bin/env bash
+# Helper script for the {{name}} skill
+set -euo pipefail
+
+echo "{{name}}: replace this script with your own logic"
+`,
+		},
+	},
+	"reference": {
+		Desc: "SKILL.md that loads detailed docs from references/",
+		Files: map[string]string{
+			"SKILL.md": `---
+name: {{name}}
+description: {{description}}
+---
+
+# {{title}}
+
+## When to use
+
+Describe the situations in which this skill should be applied.
+
+## Instructions
+
+Keep this file short. Read ` + "`references/guide.md`" + ` only when details are needed.
+`,
+			"references/guide.md": `# {{title}} Guide
+
+Detailed reference material for the {{name}} skill.
+`,
+		},
+	},
+}
+
+// BuiltinTemplateNames 返回内置模板名称（有序）
+func BuiltinTemplateNames() []string {
+	names := make([]string, 0, len(builtinTemplates))
+	for name := range builtinTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BuiltinTemplateDesc 返回内置模板描述
+func BuiltinTemplateDesc(name string) string {
+	return builtinTemplates[name].Desc
+}
+
+// Scaffold 生成新的 skill 目录
+// 目标目录已存在且非空时返回错误，避免覆盖已有内容
+func Scaffold(opts ScaffoldOptions) error {
+	if err := ValidateName(opts.Name); err != nil {
+		return err
+	}
+	if entries, err := os.ReadDir(opts.Dir); err == nil && len(entries) > 0 {
+		return fmt.Errorf("directory already exists and is not empty: %s", opts.Dir)
+	}
+
+	replacer := newTemplateRenderer(opts)
+
+	if opts.TemplateDir != "" {
+		if err := ValidateSkillDir(opts.TemplateDir); err != nil {
+			return fmt.Errorf("invalid template: %w", err)
+		}
+		if err := CopyDir(opts.TemplateDir, opts.Dir, DefaultCopyOptions()); err != nil {
+			return err
+		}
+		if err := renderTemplateFiles(opts.Dir, replacer); err != nil {
+			return err
+		}
+	} else {
+		tmpl, ok := builtinTemplates[opts.Template]
+		if !ok {
+			return fmt.Errorf("unknown template: %s, available templates: %s", opts.Template, strings.Join(BuiltinTemplateNames(), ", "))
+		}
+		for rel, content := range tmpl.Files {
+			path := filepath.Join(opts.Dir, filepath.FromSlash(rel))
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			mode := os.FileMode(0644)
+			if strings.HasPrefix(rel, "scripts/") {
+				mode = 0755
+			}
+			if err := os.WriteFile(path, []byte(replacer.Render(content)), mode); err != nil {
+				return fmt.Errorf("failed to write %s: %w", rel, err)
+			}
+		}
+	}
+
+	for _, folder := range opts.Folders {
+		if err := os.MkdirAll(filepath.Join(opts.Dir, folder), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", folder, err)
+		}
+	}
+
+	// 关键步骤：模板 SKILL.md 的 name 必须与新名称一致
+	meta, err := ReadMetadata(opts.Dir)
+	if err != nil {
+		return err
+	}
+	if meta.Name != opts.Name {
+		return fmt.Errorf("template SKILL.md name %q does not match %q, use {{name}} in the template", meta.Name, opts.Name)
+	}
+	return nil
+}
+
+// renderTemplateFiles 替换用户模板中文本文件的占位符
+func renderTemplateFiles(dir string, replacer templateRenderer) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		// 跳过二进制文件
+		if bytes.IndexByte(content, 0) >= 0 || !utf8.Valid(content) {
+			return nil
+		}
+		rendered := replacer.Render(string(content))
+		if rendered == string(content) {
+			return nil
+		}
+		return os.WriteFile(path, []byte(rendered), info.Mode())
+	})
+}
+
+// templateRenderer 替换模板中的 {{name}}/{{title}}/{{description}} 占位符
+// frontmatter 中的描述按 YAML 规则加引号，正文与其他文件使用原文
+type templateRenderer struct {
+	frontmatter *strings.Replacer
+	text        *strings.Replacer
+}
+
+// newTemplateRenderer 按脚手架选项创建占位符替换器
+func newTemplateRenderer(opts ScaffoldOptions) templateRenderer {
+	title := titleFromName(opts.Name)
+	return templateRenderer{
+		frontmatter: strings.NewReplacer(
+			"{{name}}", opts.Name,
+			"{{title}}", title,
+			"{{description}}", QuoteYAMLScalar(opts.Description),
+		),
+		text: strings.NewReplacer(
+			"{{name}}", opts.Name,
+			"{{title}}", title,
+			"{{description}}", opts.Description,
+		),
+	}
+}
+
+// Render 替换文件内容中的占位符
+func (r templateRenderer) Render(content string) string {
+	end := frontmatterEnd(content)
+	return r.frontmatter.Replace(content[:end]) + r.text.Replace(content[end:])
+}
+
+// frontmatterEnd 返回 frontmatter 结束分隔线的起始位置，没有 frontmatter 时返回 0
+func frontmatterEnd(content string) int {
+	if !strings.HasPrefix(content, "---\n") && !strings.HasPrefix(content, "---\r\n") {
+		return 0
+	}
+	first := strings.IndexByte(content, '\n')
+	end := strings.Index(content[first:], "\n---")
+	if end < 0 {
+		return 0
+	}
+	return first + end
+}
+
+// titleFromName 将 kebab-case 名称转换为标题
+func titleFromName(name string) string {
+	words := strings.Split(name, "-")
+	for i, w := range words {
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// QuoteYAMLScalar 在值包含 YAML 特殊字符时加引号，保证 frontmatter 可解析
+func QuoteYAMLScalar(s string) string {
+	if s == "" {
+		return s
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.ContainsAny(s, "\n\t") || strings.ContainsAny(s[:1], "[]{}&*!|>'\"%@`#,?-") {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,93 @@
+package skill
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"pdf", true},
+		{"code-review-2", true},
+		{"", false},
+		{"Code", false},
+		{"-lead", false},
+		{"trail-", false},
+		{"double--hyphen", false},
+		{"under_score", false},
+		{string(make([]byte, MaxNameLength+1)), false},
+	}
+	for _, tt := range tests {
+		if err := ValidateName(tt.name); (err == nil) != tt.valid {
+			t.Errorf("ValidateName(%q) error = %v, want valid %v", tt.name, err, tt.valid)
+		}
+	}
+}
+
+func TestScaffoldQuotesDescriptionOnlyInFrontmatter(t *testing.T) {
+	const desc = "Review code: style # and bugs"
+
+	templateDir := filepath.Join(t.TempDir(), "tmpl")
+	writeSkill(t, templateDir, "name: {{name}}\ndescription: {{description}}")
+	readme := "# {{title}}\n\n{{description}}\n"
+	if err := os.WriteFile(filepath.Join(templateDir, "README.md"), []byte(readme), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "code-review")
+	err := Scaffold(ScaffoldOptions{Name: "code-review", Description: desc, Dir: dir, TemplateDir: templateDir})
+	if err != nil {
+		t.Fatalf("Scaffold() error = %v", err)
+	}
+
+	meta, err := ReadMetadata(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Description != desc {
+		t.Errorf("frontmatter description = %q, want %q", meta.Description, desc)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# Code Review\n\n" + desc + "\n"; string(got) != want {
+		t.Errorf("README.md = %q, want %q", got, want)
+	}
+}
+
+func TestScaffoldBuiltinTemplates(t *testing.T) {
+	for _, tmpl := range BuiltinTemplateNames() {
+		t.Run(tmpl, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "demo")
+			err := Scaffold(ScaffoldOptions{Name: "demo", Description: "key: value", Dir: dir, Template: tmpl, Folders: []string{"assets"}})
+			if err != nil {
+				t.Fatalf("Scaffold() error = %v", err)
+			}
+			meta, err := ReadMetadata(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if meta.Name != "demo" || meta.Description != "key: value" {
+				t.Errorf("metadata = %q/%q, want demo/key: value", meta.Name, meta.Description)
+			}
+			if info, err := os.Stat(filepath.Join(dir, "assets")); err != nil || !info.IsDir() {
+				t.Errorf("assets folder not created: %v", err)
+			}
+		})
+	}
+}
+
+func TestScaffoldRefusesNonEmptyDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "keep.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Scaffold(ScaffoldOptions{Name: "demo", Dir: dir, Template: "basic"}); err == nil {
+		t.Error("Scaffold() error = nil, want error for non-empty directory")
+	}
+}
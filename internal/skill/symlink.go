@@ -0,0 +1,100 @@
+package skill
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SymlinkPolicy 拷贝时对符号链接的处理方式
+type SymlinkPolicy string
+
+const (
+	// SymlinkDereference 拷贝链接指向的内容（仅允许指向 skill 目录内部）
+	SymlinkDereference SymlinkPolicy = "dereference"
+	// SymlinkPreserve 保留链接本身，目标统一改写为相对路径（仅允许指向 skill 目录内部且未被排除的文件）
+	SymlinkPreserve SymlinkPolicy = "preserve"
+	// SymlinkReject 遇到任何符号链接即报错
+	SymlinkReject SymlinkPolicy = "reject"
+)
+
+// SymlinkPolicies 支持的策略列表
+var SymlinkPolicies = []SymlinkPolicy{SymlinkDereference, SymlinkPreserve, SymlinkReject}
+
+// ParseSymlinkPolicy 解析符号链接策略
+func ParseSymlinkPolicy(s string) (SymlinkPolicy, error) {
+	for _, p := range SymlinkPolicies {
+		if strings.EqualFold(s, string(p)) {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("unknown symlink policy: %s (supported: dereference, preserve, reject)", s)
+}
+
+// ResolveLink 解析链接的最终目标，并确保其位于 root 内
+// 入参: root 已解析的拷贝根目录真实路径，link 链接路径，rel 链接相对根目录的路径（用于报错）
+// 返回: 目标真实路径与文件信息
+func ResolveLink(root, link, rel string) (string, os.FileInfo, error) {
+	target, err := filepath.EvalSymlinks(link)
+	if err != nil {
+		return "", nil, fmt.Errorf("broken symlink %s: %w", rel, err)
+	}
+	if !isWithin(root, target) {
+		return "", nil, fmt.Errorf("refusing symlink %s: target %s is outside the skill directory", rel, target)
+	}
+	info, err := os.Stat(target)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to stat symlink target %s: %w", rel, err)
+	}
+	return target, info, nil
+}
+
+// isWithin 判断 path 是否等于 root 或位于 root 内
+func isWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
+// copySymlink 按策略拷贝单个符号链接
+func (c *copier) copySymlink(srcPath, dstPath, rel string) error {
+	if c.opts.Symlinks == SymlinkReject {
+		return fmt.Errorf("refusing symlink %s: symlinks are not allowed", rel)
+	}
+
+	target, info, err := ResolveLink(c.root, srcPath, rel)
+	if err != nil {
+		return err
+	}
+
+	if c.opts.Symlinks == SymlinkPreserve {
+		// 目标被排除时不会出现在拷贝中，保留链接会得到悬空链接
+		if targetRel, err := filepath.Rel(c.root, target); err == nil && targetRel != "." {
+			if c.opts.excludesPath(filepath.ToSlash(targetRel), info.IsDir()) {
+				return fmt.Errorf("refusing symlink %s: target %s is excluded from the copy", rel, filepath.ToSlash(targetRel))
+			}
+		}
+		// 关键步骤：以相对路径重建链接，安装后仍指向拷贝内的同一位置
+		realDir, err := filepath.EvalSymlinks(filepath.Dir(srcPath))
+		if err != nil {
+			return err
+		}
+		linkTarget, err := filepath.Rel(realDir, target)
+		if err != nil {
+			return err
+		}
+		os.Remove(dstPath)
+		if err := os.Symlink(linkTarget, dstPath); err != nil {
+			return fmt.Errorf("failed to create symlink %s: %w", rel, err)
+		}
+		return nil
+	}
+
+	if info.IsDir() {
+		return c.copyDir(target, dstPath, rel)
+	}
+	return CopyFile(target, dstPath)
+}
@@ -0,0 +1,149 @@
+package target
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// agentsProvider 实现跨 AI 编码代理通用目录的 ToolProvider 接口
+// 多个工具（如 Goose）会读取该目录，安装到这里即可被这些工具共同加载：
+// - 全局 Skills: ~/.config/agents/skills/（遵循 XDG_CONFIG_HOME）
+// - 项目级 Skills: .agents/skills/
+type agentsProvider struct {
+	homeDir string
+}
+
+// NewAgentsProvider 创建通用目录 Provider 实例
+func NewAgentsProvider() ToolProvider {
+	homeDir, _ := os.UserHomeDir()
+	return &agentsProvider{homeDir: homeDir}
+}
+
+// Type 返回工具类型枚举
+func (a *agentsProvider) Type() ToolType {
+	return ToolAgents
+}
+
+// DisplayName 返回用户可见名称
+func (a *agentsProvider) DisplayName() string {
+	return "Agents (shared)"
+}
+
+// ShortName 返回与读取共享目录的工具合并显示时的简短名称
+func (a *agentsProvider) ShortName() string {
+	return "Agents"
+}
+
+// DirSource 返回全局目录的来源
+func (a *agentsProvider) DirSource() string {
+	_, source := configHome(a.homeDir)
+	return source
+}
+
+// GlobalSkillsDir 返回全局 skills 扫描目录
+func (a *agentsProvider) GlobalSkillsDir() (string, error) {
+	base, _ := configHome(a.homeDir)
+	return filepath.Join(base, "agents", "skills"), nil
+}
+
+// GlobalInstallDir 返回全局安装目录（与扫描目录相同）
+func (a *agentsProvider) GlobalInstallDir() (string, error) {
+	return a.GlobalSkillsDir()
+}
+
+// LocalSkillsDir 返回项目级 skills 目录
+func (a *agentsProvider) LocalSkillsDir(projectRoot string) string {
+	return filepath.Join(projectRoot, ".agents", "skills")
+}
+
+// Categories 返回分类子目录列表（通用目录无分类）
+func (a *agentsProvider) Categories() []string {
+	return nil
+}
+
+// EnsureInstallDir 确保全局安装目录存在
+func (a *agentsProvider) EnsureInstallDir() (string, error) {
+	dir, err := a.GlobalInstallDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// EnsureLocalInstallDir 确保项目级安装目录存在
+func (a *agentsProvider) EnsureLocalInstallDir(projectRoot string) (string, error) {
+	dir := a.LocalSkillsDir(projectRoot)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// Detect 通用目录已有内容时视为在用
+func (a *agentsProvider) Detect() bool {
+	base, _ := configHome(a.homeDir)
+	return detection{configDir: filepath.Join(base, "agents")}.detect(a.homeDir)
+}
+
+// ReadsSharedDir 判断工具是否会加载 agents 通用目录
+// global、project 分别要求工具的搜索路径包含全局目录与项目级目录
+func ReadsSharedDir(p ToolProvider, global bool, projectRoot string) bool {
+	if p.Type() == ToolAgents {
+		return false
+	}
+	shared := NewAgentsProvider()
+	var want []string
+	if global {
+		dir, _ := shared.GlobalSkillsDir()
+		want = append(want, resolveDir(dir))
+	}
+	if projectRoot != "" {
+		want = append(want, resolveDir(shared.LocalSkillsDir(projectRoot)))
+	}
+	if len(want) == 0 {
+		return false
+	}
+
+	searched := make(map[string]bool)
+	for _, sp := range SearchPaths(p, projectRoot) {
+		searched[resolveDir(sp.Dir)] = true
+	}
+	for _, dir := range want {
+		if !searched[dir] {
+			return false
+		}
+	}
+	return true
+}
+
+// CollapseShared 将读取 agents 通用目录的工具并入 agents 目标，只安装到共享目录一次
+// 仅当 providers 包含 agents 目标时生效，合并后的工具位于 agents 的位置
+// 返回: 处理后的工具列表
+func CollapseShared(providers []ToolProvider, global bool, projectRoot string) []ToolProvider {
+	idx := slices.IndexFunc(providers, func(p ToolProvider) bool { return p.Type() == ToolAgents })
+	if idx < 0 {
+		return providers
+	}
+
+	var readers, result []ToolProvider
+	pos := 0
+	for i, p := range providers {
+		switch {
+		case i == idx:
+			pos = len(result)
+		case ReadsSharedDir(p, global, projectRoot):
+			readers = append(readers, Members(p)...)
+		default:
+			result = append(result, p)
+		}
+	}
+	if len(readers) == 0 {
+		return providers
+	}
+	members := append([]ToolProvider{providers[idx]}, readers...)
+	return slices.Insert(result, pos, ToolProvider(&sharedProvider{members: members}))
+}
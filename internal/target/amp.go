@@ -0,0 +1,108 @@
+package target
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// ampProvider 实现 Amp 的 ToolProvider 接口
+// Amp 直接读取跨 AI 编码代理的通用目录（按优先级）：
+// - 项目级 Skills: .agents/skills/，兼容 .claude/skills/
+// - 全局 Skills: ~/.config/agents/skills/（遵循 XDG_CONFIG_HOME），兼容 ~/.claude/skills/
+// SkillSync 安装到通用目录，与 agents 目标共享
+type ampProvider struct {
+	homeDir string
+}
+
+// NewAmpProvider 创建 Amp Provider 实例
+func NewAmpProvider() ToolProvider {
+	homeDir, _ := os.UserHomeDir()
+	return &ampProvider{homeDir: homeDir}
+}
+
+// Type 返回工具类型枚举
+func (a *ampProvider) Type() ToolType {
+	return ToolAmp
+}
+
+// DisplayName 返回用户可见名称
+func (a *ampProvider) DisplayName() string {
+	return "Amp"
+}
+
+// DirSource 返回全局目录的来源
+func (a *ampProvider) DirSource() string {
+	_, source := configHome(a.homeDir)
+	return source
+}
+
+// GlobalSkillsDir 返回全局 skills 扫描目录
+// Amp 使用 ~/.config/agents/skills/
+func (a *ampProvider) GlobalSkillsDir() (string, error) {
+	base, _ := configHome(a.homeDir)
+	return filepath.Join(base, "agents", "skills"), nil
+}
+
+// GlobalInstallDir 返回全局安装目录（与扫描目录相同）
+func (a *ampProvider) GlobalInstallDir() (string, error) {
+	return a.GlobalSkillsDir()
+}
+
+// LocalSkillsDir 返回项目级 skills 目录
+// Amp 使用 .agents/skills/
+func (a *ampProvider) LocalSkillsDir(projectRoot string) string {
+	return filepath.Join(projectRoot, ".agents", "skills")
+}
+
+// Categories 返回分类子目录列表（Amp 无分类）
+func (a *ampProvider) Categories() []string {
+	return nil
+}
+
+// SearchPaths 返回 Amp 加载 skill 的目录（按优先级）
+func (a *ampProvider) SearchPaths(projectRoot string) []SearchPath {
+	var paths []SearchPath
+	if projectRoot != "" {
+		paths = append(paths,
+			SearchPath{Dir: a.LocalSkillsDir(projectRoot), Scope: ScopeProject},
+			SearchPath{Dir: filepath.Join(projectRoot, ".claude", "skills"), Scope: ScopeProject},
+		)
+	}
+	globalDir, _ := a.GlobalSkillsDir()
+	claudeDir, _ := claudeHome(a.homeDir)
+	return append(paths,
+		SearchPath{Dir: globalDir, Scope: ScopeShared},
+		SearchPath{Dir: filepath.Join(claudeDir, "skills"), Scope: ScopeShared},
+	)
+}
+
+// EnsureInstallDir 确保全局安装目录存在
+func (a *ampProvider) EnsureInstallDir() (string, error) {
+	dir, err := a.GlobalInstallDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// EnsureLocalInstallDir 确保项目级安装目录存在
+func (a *ampProvider) EnsureLocalInstallDir(projectRoot string) (string, error) {
+	dir := a.LocalSkillsDir(projectRoot)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// Detect 检测 Amp 是否已安装：$XDG_CONFIG_HOME/amp、amp 命令或 Sourcegraph Amp 编辑器扩展
+func (a *ampProvider) Detect() bool {
+	base, _ := configHome(a.homeDir)
+	return detection{
+		configDir:  filepath.Join(base, "amp"),
+		binaries:   []string{"amp"},
+		extensions: []string{"sourcegraph.amp"},
+	}.detect(a.homeDir)
+}
@@ -71,3 +71,12 @@ func (a *antigravityProvider) EnsureLocalInstallDir(projectRoot string) (string,
 	}
 	return dir, nil
 }
+
+// Detect 检测 Antigravity 是否已安装：~/.gemini/antigravity 或 antigravity 命令
+func (a *antigravityProvider) Detect() bool {
+	return detection{
+		configDir: ".gemini/antigravity",
+		ownDirs:   []string{"skills"},
+		binaries:  []string{"antigravity"},
+	}.detect(a.homeDir)
+}
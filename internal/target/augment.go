@@ -0,0 +1,84 @@
+package target
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// augmentProvider 实现 Augment 的 ToolProvider 接口
+// Augment（VSCode / JetBrains 扩展与 Auggie CLI）使用以下目录结构：
+// - 全局 Skills: ~/.augment/skills/
+// - 项目级 Skills: .augment/skills/
+// 规则文件位于 .augment/rules/，SkillSync 仅管理 skills 目录
+type augmentProvider struct {
+	homeDir string
+}
+
+// NewAugmentProvider 创建 Augment Provider 实例
+func NewAugmentProvider() ToolProvider {
+	homeDir, _ := os.UserHomeDir()
+	return &augmentProvider{homeDir: homeDir}
+}
+
+// Type 返回工具类型枚举
+func (a *augmentProvider) Type() ToolType {
+	return ToolAugment
+}
+
+// DisplayName 返回用户可见名称
+func (a *augmentProvider) DisplayName() string {
+	return "Augment Code"
+}
+
+// GlobalSkillsDir 返回全局 skills 扫描目录
+// Augment 使用 ~/.augment/skills/
+func (a *augmentProvider) GlobalSkillsDir() (string, error) {
+	return filepath.Join(a.homeDir, ".augment", "skills"), nil
+}
+
+// GlobalInstallDir 返回全局安装目录（与扫描目录相同）
+func (a *augmentProvider) GlobalInstallDir() (string, error) {
+	return a.GlobalSkillsDir()
+}
+
+// LocalSkillsDir 返回项目级 skills 目录
+// Augment 使用 .augment/skills/
+func (a *augmentProvider) LocalSkillsDir(projectRoot string) string {
+	return filepath.Join(projectRoot, ".augment", "skills")
+}
+
+// Categories 返回分类子目录列表（Augment 无分类）
+func (a *augmentProvider) Categories() []string {
+	return nil
+}
+
+// EnsureInstallDir 确保全局安装目录存在
+func (a *augmentProvider) EnsureInstallDir() (string, error) {
+	dir, err := a.GlobalInstallDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// EnsureLocalInstallDir 确保项目级安装目录存在
+func (a *augmentProvider) EnsureLocalInstallDir(projectRoot string) (string, error) {
+	dir := a.LocalSkillsDir(projectRoot)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// Detect 检测 Augment 是否已安装：~/.augment、auggie 命令或 Augment 编辑器扩展
+func (a *augmentProvider) Detect() bool {
+	return detection{
+		configDir:  ".augment",
+		ownDirs:    []string{"skills"},
+		binaries:   []string{"auggie"},
+		extensions: []string{"augment.vscode-augment"},
+	}.detect(a.homeDir)
+}
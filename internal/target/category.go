@@ -0,0 +1,38 @@
+package target
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// CategoryProtector 包含只读分类的工具
+// 只读分类由工具自身管理（如 Codex 的 .system），SkillSync 默认不写入或删除其中的 skill
+type CategoryProtector interface {
+	// ProtectedCategories 返回只读分类列表
+	ProtectedCategories() []string
+}
+
+// IsProtectedCategory 判断分类是否只读
+func IsProtectedCategory(p ToolProvider, category string) bool {
+	if cp, ok := p.(CategoryProtector); ok {
+		return slices.Contains(cp.ProtectedCategories(), category)
+	}
+	return false
+}
+
+// CategoryDir 返回全局 skills 目录下指定分类的目录
+func CategoryDir(p ToolProvider, category string) (string, error) {
+	if !slices.Contains(p.Categories(), category) {
+		if len(p.Categories()) == 0 {
+			return "", fmt.Errorf("%s has no categories", p.DisplayName())
+		}
+		return "", fmt.Errorf("unknown category %s for %s (available: %s)", category, p.DisplayName(), strings.Join(p.Categories(), ", "))
+	}
+	root, err := p.GlobalSkillsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, category), nil
+}
@@ -6,6 +6,7 @@ import (
 )
 
 // claudeProvider 实现 Claude Code 的 ToolProvider 接口
+// 配置目录默认为 ~/.claude/，可通过 CLAUDE_CONFIG_DIR 覆盖
 type claudeProvider struct {
 	homeDir string
 }
@@ -26,9 +27,21 @@ func (c *claudeProvider) DisplayName() string {
 	return "Claude Code"
 }
 
+// configDir 返回 Claude Code 配置目录及其来源
+func (c *claudeProvider) configDir() (string, string) {
+	return claudeHome(c.homeDir)
+}
+
+// DirSource 返回全局目录的来源
+func (c *claudeProvider) DirSource() string {
+	_, source := c.configDir()
+	return source
+}
+
 // GlobalSkillsDir 返回全局 skills 扫描目录
 func (c *claudeProvider) GlobalSkillsDir() (string, error) {
-	return filepath.Join(c.homeDir, ".claude", "skills"), nil
+	dir, _ := c.configDir()
+	return filepath.Join(dir, "skills"), nil
 }
 
 // GlobalInstallDir 返回全局安装目录（与扫描目录相同）
@@ -66,3 +79,13 @@ func (c *claudeProvider) EnsureLocalInstallDir(projectRoot string) (string, erro
 	}
 	return dir, nil
 }
+
+// Detect 检测 Claude Code 是否已安装：Claude 配置目录（CLAUDE_CONFIG_DIR 或 ~/.claude）或 claude 命令
+func (c *claudeProvider) Detect() bool {
+	dir, _ := c.configDir()
+	return detection{
+		configDir: dir,
+		ownDirs:   []string{"skills"},
+		binaries:  []string{"claude"},
+	}.detect(c.homeDir)
+}
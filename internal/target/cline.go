@@ -73,3 +73,13 @@ func (c *clineProvider) EnsureLocalInstallDir(projectRoot string) (string, error
 	}
 	return dir, nil
 }
+
+// Detect 检测 Cline 是否已安装：~/.cline、cline 命令或 Cline 编辑器扩展
+func (c *clineProvider) Detect() bool {
+	return detection{
+		configDir:  ".cline",
+		ownDirs:    []string{"skills"},
+		binaries:   []string{"cline"},
+		extensions: []string{"saoudrizwan.claude-dev"},
+	}.detect(c.homeDir)
+}
@@ -7,6 +7,7 @@ import (
 
 // codexProvider 实现 Codex CLI 的 ToolProvider 接口
 // Codex 有特殊的目录结构：安装到 public/ 子目录，同时支持 .system/ 分类
+// 配置目录默认为 ~/.codex/，可通过 CODEX_HOME 覆盖
 type codexProvider struct {
 	homeDir string
 }
@@ -27,16 +28,29 @@ func (c *codexProvider) DisplayName() string {
 	return "Codex CLI"
 }
 
+// codexHome 返回 Codex 配置目录及其来源
+func (c *codexProvider) codexHome() (string, string) {
+	return envDir("CODEX_HOME", c.homeDir, ".codex")
+}
+
+// DirSource 返回全局目录的来源
+func (c *codexProvider) DirSource() string {
+	_, source := c.codexHome()
+	return source
+}
+
 // GlobalSkillsDir 返回全局 skills 扫描目录
 // 返回根目录，扫描时会递归查找 public/ 和 .system/ 子目录
 func (c *codexProvider) GlobalSkillsDir() (string, error) {
-	return filepath.Join(c.homeDir, ".codex", "skills"), nil
+	dir, _ := c.codexHome()
+	return filepath.Join(dir, "skills"), nil
 }
 
 // GlobalInstallDir 返回全局安装目录
 // Codex 用户 skills 默认安装到 public/ 子目录
 func (c *codexProvider) GlobalInstallDir() (string, error) {
-	return filepath.Join(c.homeDir, ".codex", "skills", "public"), nil
+	dir, _ := c.codexHome()
+	return filepath.Join(dir, "skills", "public"), nil
 }
 
 // LocalSkillsDir 返回项目级 skills 目录
@@ -49,6 +63,11 @@ func (c *codexProvider) Categories() []string {
 	return []string{"public", ".system"}
 }
 
+// ProtectedCategories 返回只读分类（.system 由 Codex 自身管理）
+func (c *codexProvider) ProtectedCategories() []string {
+	return []string{".system"}
+}
+
 // EnsureInstallDir 确保全局安装目录存在
 func (c *codexProvider) EnsureInstallDir() (string, error) {
 	dir, err := c.GlobalInstallDir()
@@ -69,3 +88,13 @@ func (c *codexProvider) EnsureLocalInstallDir(projectRoot string) (string, error
 	}
 	return dir, nil
 }
+
+// Detect 检测 Codex CLI 是否已安装：CODEX_HOME（默认 ~/.codex）或 codex 命令
+func (c *codexProvider) Detect() bool {
+	dir, _ := c.codexHome()
+	return detection{
+		configDir: dir,
+		ownDirs:   []string{"skills"},
+		binaries:  []string{"codex"},
+	}.detect(c.homeDir)
+}
@@ -29,6 +29,11 @@ func (c *copilotProvider) DisplayName() string {
 	return "GitHub Copilot / VSCode"
 }
 
+// ShortName 返回与 VSCode 合并显示时的简短名称
+func (c *copilotProvider) ShortName() string {
+	return "Copilot"
+}
+
 // GlobalSkillsDir 返回全局 skills 扫描目录
 // GitHub Copilot 使用 ~/.copilot/skills/
 func (c *copilotProvider) GlobalSkillsDir() (string, error) {
@@ -71,3 +76,13 @@ func (c *copilotProvider) EnsureLocalInstallDir(projectRoot string) (string, err
 	}
 	return dir, nil
 }
+
+// Detect 检测 GitHub Copilot 是否已安装：~/.copilot、copilot 命令或 Copilot 编辑器扩展
+func (c *copilotProvider) Detect() bool {
+	return detection{
+		configDir:  ".copilot",
+		ownDirs:    []string{"skills"},
+		binaries:   []string{"copilot"},
+		extensions: []string{"github.copilot", "github.copilot-chat"},
+	}.detect(c.homeDir)
+}
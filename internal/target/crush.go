@@ -8,7 +8,7 @@ import (
 // crushProvider 实现 Crush 终端工具的 ToolProvider 接口
 // Crush 是 Charmbracelet 出品的终端 AI 编程助手
 // 使用以下目录结构：
-// - 全局 Skills: ~/.config/crush/skills/
+// - 全局 Skills: ~/.config/crush/skills/（遵循 XDG_CONFIG_HOME，可通过 CRUSH_SKILLS_DIR 覆盖）
 // - 项目级 Skills: .crush/skills/
 type crushProvider struct {
 	homeDir string
@@ -30,10 +30,26 @@ func (c *crushProvider) DisplayName() string {
 	return "Crush"
 }
 
+// skillsDir 返回全局 skills 目录及其来源
+func (c *crushProvider) skillsDir() (string, string) {
+	if dir, source := envDir("CRUSH_SKILLS_DIR", c.homeDir); source != SourceDefault {
+		return dir, source
+	}
+	base, source := configHome(c.homeDir)
+	return filepath.Join(base, "crush", "skills"), source
+}
+
+// DirSource 返回全局目录的来源
+func (c *crushProvider) DirSource() string {
+	_, source := c.skillsDir()
+	return source
+}
+
 // GlobalSkillsDir 返回全局 skills 扫描目录
 // Crush 使用 ~/.config/crush/skills/
 func (c *crushProvider) GlobalSkillsDir() (string, error) {
-	return filepath.Join(c.homeDir, ".config", "crush", "skills"), nil
+	dir, _ := c.skillsDir()
+	return dir, nil
 }
 
 // GlobalInstallDir 返回全局安装目录（与扫描目录相同）
@@ -72,3 +88,13 @@ func (c *crushProvider) EnsureLocalInstallDir(projectRoot string) (string, error
 	}
 	return dir, nil
 }
+
+// Detect 检测 Crush 是否已安装：$XDG_CONFIG_HOME/crush 或 crush 命令
+func (c *crushProvider) Detect() bool {
+	base, _ := configHome(c.homeDir)
+	return detection{
+		configDir: filepath.Join(base, "crush"),
+		ownDirs:   []string{"skills"},
+		binaries:  []string{"crush"},
+	}.detect(c.homeDir)
+}
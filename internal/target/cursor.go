@@ -9,6 +9,7 @@ import (
 // Cursor 使用以下目录结构：
 // - 全局 Skills: ~/.cursor/skills/
 // - 项目级 Skills: .cursor/skills/
+// - 项目级 Rules: .cursor/rules/*.mdc（install --format cursor-rules）
 type cursorProvider struct {
 	homeDir string
 }
@@ -46,6 +47,12 @@ func (c *cursorProvider) LocalSkillsDir(projectRoot string) string {
 	return filepath.Join(projectRoot, ".cursor", "skills")
 }
 
+// LocalRulesDir 返回项目级规则目录
+// Cursor 使用 .cursor/rules/（install --format cursor-rules）
+func (c *cursorProvider) LocalRulesDir(projectRoot string) string {
+	return filepath.Join(projectRoot, ".cursor", "rules")
+}
+
 // Categories 返回分类子目录列表（Cursor 无分类）
 func (c *cursorProvider) Categories() []string {
 	return nil
@@ -71,3 +78,12 @@ func (c *cursorProvider) EnsureLocalInstallDir(projectRoot string) (string, erro
 	}
 	return dir, nil
 }
+
+// Detect 检测 Cursor 是否已安装：~/.cursor 或 cursor 命令
+func (c *cursorProvider) Detect() bool {
+	return detection{
+		configDir: ".cursor",
+		ownDirs:   []string{"skills"},
+		binaries:  []string{"cursor"},
+	}.detect(c.homeDir)
+}
@@ -0,0 +1,129 @@
+package target
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// CustomSpec 用户在配置文件中声明的自定义工具
+type CustomSpec struct {
+	ID          string   // 工具标识，用于 --target
+	DisplayName string   // 用户可见名称，为空时使用 ID
+	GlobalDir   string   // 全局 skills 扫描目录
+	InstallDir  string   // 全局安装目录，为空时与扫描目录相同
+	LocalDir    string   // 项目级目录模板，相对路径基于项目根目录，支持 {project} 占位符
+	Categories  []string // 分类子目录
+}
+
+// customIDPattern 自定义工具 ID 格式
+var customIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// customProvider 基于配置的 ToolProvider 实现
+type customProvider struct {
+	spec CustomSpec
+}
+
+// NewCustomProvider 根据配置创建自定义工具 Provider
+// 路径支持 ~ 与环境变量（$VAR）
+func NewCustomProvider(spec CustomSpec) (ToolProvider, error) {
+	spec.ID = strings.ToLower(strings.TrimSpace(spec.ID))
+	if !customIDPattern.MatchString(spec.ID) {
+		return nil, fmt.Errorf("invalid target id %q: use lowercase letters, digits and hyphens", spec.ID)
+	}
+	if err := checkReservedID(spec.ID); err != nil {
+		return nil, err
+	}
+	if spec.GlobalDir == "" {
+		return nil, fmt.Errorf("target %s: global-dir is required", spec.ID)
+	}
+	spec.GlobalDir = expandPath(spec.GlobalDir)
+	if spec.InstallDir == "" {
+		spec.InstallDir = spec.GlobalDir
+	} else {
+		spec.InstallDir = expandPath(spec.InstallDir)
+	}
+	if spec.DisplayName == "" {
+		spec.DisplayName = spec.ID
+	}
+	return &customProvider{spec: spec}, nil
+}
+
+// expandPath 展开路径开头的 ~ 与环境变量
+func expandPath(p string) string {
+	p = os.ExpandEnv(p)
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			p = filepath.Join(homeDir, strings.TrimPrefix(p, "~"))
+		}
+	}
+	return filepath.Clean(p)
+}
+
+// Type 返回工具类型
+func (c *customProvider) Type() ToolType {
+	return ToolType(c.spec.ID)
+}
+
+// DisplayName 返回用户可见名称
+func (c *customProvider) DisplayName() string {
+	return c.spec.DisplayName
+}
+
+// DirSource 返回目录来源（配置文件）
+func (c *customProvider) DirSource() string {
+	return "config.yaml"
+}
+
+// GlobalSkillsDir 返回全局 skills 扫描目录
+func (c *customProvider) GlobalSkillsDir() (string, error) {
+	return c.spec.GlobalDir, nil
+}
+
+// GlobalInstallDir 返回全局安装目录
+func (c *customProvider) GlobalInstallDir() (string, error) {
+	return c.spec.InstallDir, nil
+}
+
+// LocalSkillsDir 返回项目级 skills 目录，未配置时返回空
+func (c *customProvider) LocalSkillsDir(projectRoot string) string {
+	if c.spec.LocalDir == "" {
+		return ""
+	}
+	dir := strings.ReplaceAll(c.spec.LocalDir, "{project}", projectRoot)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(projectRoot, dir)
+	}
+	return filepath.Clean(dir)
+}
+
+// Categories 返回分类子目录列表
+func (c *customProvider) Categories() []string {
+	return c.spec.Categories
+}
+
+// EnsureInstallDir 确保全局安装目录存在
+func (c *customProvider) EnsureInstallDir() (string, error) {
+	dir, err := c.GlobalInstallDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// EnsureLocalInstallDir 确保项目级安装目录存在
+func (c *customProvider) EnsureLocalInstallDir(projectRoot string) (string, error) {
+	dir := c.LocalSkillsDir(projectRoot)
+	if dir == "" {
+		return "", fmt.Errorf("no local-dir configured for %s", c.spec.ID)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
@@ -0,0 +1,44 @@
+package target
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewCustomProvider(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		spec    CustomSpec
+		wantErr string
+	}{
+		{"valid", CustomSpec{ID: "My-Tool", GlobalDir: dir}, ""},
+		{"invalid id", CustomSpec{ID: "my_tool", GlobalDir: dir}, "invalid target id"},
+		{"missing global dir", CustomSpec{ID: "my-tool"}, "global-dir is required"},
+		{"detected is reserved", CustomSpec{ID: TargetDetected, GlobalDir: dir}, "is reserved"},
+		{"all is reserved", CustomSpec{ID: TargetAll, GlobalDir: dir}, "is reserved"},
+		{"builtin alias", CustomSpec{ID: "claude-code", GlobalDir: dir}, "is an alias of claude"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewCustomProvider(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("NewCustomProvider() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewCustomProvider() error = %v", err)
+			}
+			if p.Type() != "my-tool" {
+				t.Errorf("Type() = %q, want %q", p.Type(), "my-tool")
+			}
+			if got, _ := p.GlobalInstallDir(); got != filepath.Clean(dir) {
+				t.Errorf("GlobalInstallDir() = %q, want %q", got, dir)
+			}
+		})
+	}
+}
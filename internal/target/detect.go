@@ -0,0 +1,108 @@
+package target
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Detector 可检测自身是否已安装的工具
+// 未实现该接口的工具（配置文件中的自定义工具、外部插件）视为已安装
+type Detector interface {
+	// Detect 检测工具是否安装在当前机器上
+	Detect() bool
+}
+
+// detection 工具安装痕迹
+type detection struct {
+	configDir  string   // 工具配置目录（相对 home，或环境变量解析出的绝对路径）
+	ownDirs    []string // 配置目录中由 SkillSync 创建的子目录，不作为安装依据
+	binaries   []string // PATH 中的可执行文件
+	extensions []string // 编辑器扩展 ID（publisher.name）
+}
+
+// editorExtensionDirs 编辑器扩展目录（相对 home）
+var editorExtensionDirs = []string{
+	".vscode/extensions",
+	".vscode-insiders/extensions",
+	".vscode-server/extensions",
+	".cursor/extensions",
+	".windsurf/extensions",
+}
+
+// detect 按配置目录、可执行文件、编辑器扩展依次检测
+func (d detection) detect(homeDir string) bool {
+	if d.configDir != "" {
+		dir := d.configDir
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(homeDir, dir)
+		}
+		if hasOwnEntries(dir, d.ownDirs) {
+			return true
+		}
+	}
+	for _, bin := range d.binaries {
+		if _, err := exec.LookPath(bin); err == nil {
+			return true
+		}
+	}
+	for _, ext := range d.extensions {
+		if hasExtension(homeDir, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasOwnEntries 判断目录存在且包含 SkillSync 创建的子目录以外的内容
+// 避免把 SkillSync 此前创建的 skills 目录误判为工具已安装
+func hasOwnEntries(dir string, ownDirs []string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		if !slices.Contains(ownDirs, e.Name()) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasExtension 判断编辑器扩展目录中是否存在指定扩展（目录名形如 publisher.name-1.2.3）
+func hasExtension(homeDir, id string) bool {
+	prefix := strings.ToLower(id) + "-"
+	for _, dir := range editorExtensionDirs {
+		entries, err := os.ReadDir(filepath.Join(homeDir, dir))
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if strings.HasPrefix(strings.ToLower(e.Name()), prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsDetected 判断工具是否已安装
+func IsDetected(p ToolProvider) bool {
+	if d, ok := p.(Detector); ok {
+		return d.Detect()
+	}
+	return true
+}
+
+// DetectedProviders 返回已安装的工具（保持注册顺序）
+func DetectedProviders() []ToolProvider {
+	var result []ToolProvider
+	for _, p := range AllProviders() {
+		if IsDetected(p) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
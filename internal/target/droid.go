@@ -72,3 +72,12 @@ func (d *droidProvider) EnsureLocalInstallDir(projectRoot string) (string, error
 	}
 	return dir, nil
 }
+
+// Detect 检测 Droid 是否已安装：~/.factory 或 droid 命令
+func (d *droidProvider) Detect() bool {
+	return detection{
+		configDir: ".factory",
+		ownDirs:   []string{"skills"},
+		binaries:  []string{"droid"},
+	}.detect(d.homeDir)
+}
@@ -0,0 +1,48 @@
+package target
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DirEnvVars 内置工具解析目录时读取的环境变量
+var DirEnvVars = []string{"CLAUDE_CONFIG_DIR", "CODEX_HOME", "CRUSH_SKILLS_DIR", "XDG_CONFIG_HOME"}
+
+// SourceDefault 目录未被环境变量或配置覆盖，使用工具的默认位置
+const SourceDefault = "default"
+
+// DirSourcer 可说明全局目录来源的工具
+type DirSourcer interface {
+	// DirSource 返回全局目录的来源，如 "$CODEX_HOME" 或 SourceDefault
+	DirSource() string
+}
+
+// DirSource 返回工具全局目录的来源，未实现 DirSourcer 时为 SourceDefault
+func DirSource(p ToolProvider) string {
+	if s, ok := p.(DirSourcer); ok {
+		return s.DirSource()
+	}
+	return SourceDefault
+}
+
+// envDir 返回环境变量指定的目录，未设置或不是绝对路径时使用 home 下的默认目录
+// 返回: 目录及其来源（"$变量名" 或 SourceDefault）
+func envDir(name, homeDir string, elem ...string) (string, string) {
+	if v := os.Getenv(name); v != "" {
+		if dir := expandPath(v); filepath.IsAbs(dir) {
+			return dir, "$" + name
+		}
+	}
+	return filepath.Join(append([]string{homeDir}, elem...)...), SourceDefault
+}
+
+// configHome 返回 XDG 配置目录（$XDG_CONFIG_HOME，默认 ~/.config）
+func configHome(homeDir string) (string, string) {
+	return envDir("XDG_CONFIG_HOME", homeDir, ".config")
+}
+
+// claudeHome 返回 Claude Code 配置目录（$CLAUDE_CONFIG_DIR，默认 ~/.claude）
+// 读取 Claude skills 目录的其他工具也据此定位
+func claudeHome(homeDir string) (string, string) {
+	return envDir("CLAUDE_CONFIG_DIR", homeDir, ".claude")
+}
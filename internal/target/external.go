@@ -0,0 +1,298 @@
+package target
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ExternalPrefix 外部 provider 插件可执行文件名前缀
+// PATH 中的 skillsync-provider-<name> 注册为目标工具 <name>
+const ExternalPrefix = "skillsync-provider-"
+
+// ExternalProtocolVersion 外部 provider 协议版本
+const ExternalProtocolVersion = 1
+
+// externalTimeout 单次调用插件的超时时间
+const externalTimeout = 60 * time.Second
+
+// ErrUnsupported 插件未实现某个操作，调用方回退为默认行为（按目录拷贝或删除）
+var ErrUnsupported = errors.New("operation not supported by provider")
+
+// 协议操作，与 ToolProvider / SkillManager 方法一一对应
+const (
+	opDescribe = "describe" // Type、DisplayName、Categories
+	opDirs     = "dirs"     // GlobalSkillsDir、GlobalInstallDir、LocalSkillsDir
+	opInstall  = "install"  // InstallSkill
+	opRemove   = "remove"   // RemoveSkill
+	opList     = "list"     // ListSkills
+)
+
+// externalRequest 通过 stdin 发送给插件的请求
+type externalRequest struct {
+	Version     int    `json:"version"`
+	Op          string `json:"op"`
+	Scope       string `json:"scope,omitempty"`       // global 或 project
+	ProjectRoot string `json:"projectRoot,omitempty"` // scope 为 project 时的项目根目录
+	Name        string `json:"name,omitempty"`        // install、remove 的 skill 名称
+	Source      string `json:"source,omitempty"`      // install 的 skill 目录（已按排除规则拷贝的临时目录）
+}
+
+// externalResponse 插件写入 stdout 的响应
+type externalResponse struct {
+	Error       string `json:"error,omitempty"`
+	Unsupported bool   `json:"unsupported,omitempty"`
+
+	// describe
+	DisplayName string   `json:"displayName,omitempty"`
+	Categories  []string `json:"categories,omitempty"`
+
+	// dirs
+	GlobalSkillsDir  string `json:"globalSkillsDir,omitempty"`
+	GlobalInstallDir string `json:"globalInstallDir,omitempty"`
+	LocalSkillsDir   string `json:"localSkillsDir,omitempty"`
+
+	// install
+	Path string `json:"path,omitempty"`
+
+	// list
+	Skills []ManagedSkill `json:"skills,omitempty"`
+}
+
+// externalProvider 将外部插件适配为 ToolProvider 与 SkillManager
+type externalProvider struct {
+	name string // 工具标识（可执行文件名去掉前缀）
+	path string // 可执行文件路径
+
+	describeOnce sync.Once
+	describe     externalResponse
+	describeErr  error
+
+	mu        sync.Mutex
+	dirsCache map[string]externalResponse // key: projectRoot（全局为空）
+}
+
+// NewExternalProvider 创建外部插件 Provider
+// describe 在首次需要时才调用，避免每次启动都执行所有插件
+func NewExternalProvider(name, path string) ToolProvider {
+	return &externalProvider{name: name, path: path}
+}
+
+// DiscoverExternalProviders 在 PATH 中查找 skillsync-provider-<name> 可执行文件
+// 同名插件以 PATH 中靠前的为准
+// 返回: 工具标识到可执行文件路径的映射，以及按发现顺序排列的标识
+func DiscoverExternalProviders() (map[string]string, []string) {
+	found := make(map[string]string)
+	var order []string
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if !strings.HasPrefix(name, ExternalPrefix) || entry.IsDir() {
+				continue
+			}
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			id := strings.TrimPrefix(name, ExternalPrefix)
+			if _, ok := found[id]; ok || !customIDPattern.MatchString(id) {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if info, err := os.Stat(path); err != nil || (runtime.GOOS != "windows" && info.Mode()&0111 == 0) {
+				continue
+			}
+			found[id] = path
+			order = append(order, id)
+		}
+	}
+	return found, order
+}
+
+// call 执行一次插件调用
+func (e *externalProvider) call(req externalRequest) (externalResponse, error) {
+	req.Version = ExternalProtocolVersion
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return externalResponse{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), externalTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, e.path)
+	cmd.Stdin = bytes.NewReader(payload)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	runErr := cmd.Run()
+	var resp externalResponse
+	if err := json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &resp); err != nil {
+		if runErr != nil {
+			return resp, fmt.Errorf("%s %s failed: %v: %s", filepath.Base(e.path), req.Op, runErr, strings.TrimSpace(stderr.String()))
+		}
+		return resp, fmt.Errorf("%s %s: invalid response: %w", filepath.Base(e.path), req.Op, err)
+	}
+	if resp.Unsupported {
+		return resp, ErrUnsupported
+	}
+	if resp.Error != "" {
+		return resp, fmt.Errorf("%s %s: %s", filepath.Base(e.path), req.Op, resp.Error)
+	}
+	if runErr != nil {
+		return resp, fmt.Errorf("%s %s failed: %w", filepath.Base(e.path), req.Op, runErr)
+	}
+	return resp, nil
+}
+
+// described 返回缓存的 describe 结果
+func (e *externalProvider) described() (externalResponse, error) {
+	e.describeOnce.Do(func() {
+		e.describe, e.describeErr = e.call(externalRequest{Op: opDescribe})
+	})
+	return e.describe, e.describeErr
+}
+
+// dirs 查询插件的目录，结果按项目根目录缓存
+func (e *externalProvider) dirs(projectRoot string) (externalResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if d, ok := e.dirsCache[projectRoot]; ok {
+		return d, nil
+	}
+	d, err := e.call(scopeRequest(opDirs, projectRoot))
+	if err != nil {
+		return d, err
+	}
+	if e.dirsCache == nil {
+		e.dirsCache = make(map[string]externalResponse)
+	}
+	e.dirsCache[projectRoot] = d
+	return d, nil
+}
+
+// Type 返回工具类型
+func (e *externalProvider) Type() ToolType {
+	return ToolType(e.name)
+}
+
+// DisplayName 返回用户可见名称，插件不可用时回退为工具标识
+func (e *externalProvider) DisplayName() string {
+	if d, err := e.described(); err == nil && d.DisplayName != "" {
+		return d.DisplayName
+	}
+	return e.name
+}
+
+// DirSource 返回目录来源（插件可执行文件）
+func (e *externalProvider) DirSource() string {
+	return "plugin " + e.path
+}
+
+// GlobalSkillsDir 返回全局 skills 扫描目录
+func (e *externalProvider) GlobalSkillsDir() (string, error) {
+	d, err := e.dirs("")
+	if err != nil {
+		return "", err
+	}
+	if d.GlobalSkillsDir == "" {
+		return "", fmt.Errorf("%s: no global skills directory", e.name)
+	}
+	return d.GlobalSkillsDir, nil
+}
+
+// GlobalInstallDir 返回全局安装目录，未声明时与扫描目录相同
+func (e *externalProvider) GlobalInstallDir() (string, error) {
+	d, err := e.dirs("")
+	if err != nil {
+		return "", err
+	}
+	if d.GlobalInstallDir != "" {
+		return d.GlobalInstallDir, nil
+	}
+	if d.GlobalSkillsDir == "" {
+		return "", fmt.Errorf("%s: no global install directory", e.name)
+	}
+	return d.GlobalSkillsDir, nil
+}
+
+// LocalSkillsDir 返回项目级 skills 目录，插件不支持时返回空
+func (e *externalProvider) LocalSkillsDir(projectRoot string) string {
+	d, err := e.dirs(projectRoot)
+	if err != nil {
+		return ""
+	}
+	return d.LocalSkillsDir
+}
+
+// Categories 返回分类子目录列表
+func (e *externalProvider) Categories() []string {
+	d, _ := e.described()
+	return d.Categories
+}
+
+// EnsureInstallDir 确保全局安装目录存在
+func (e *externalProvider) EnsureInstallDir() (string, error) {
+	dir, err := e.GlobalInstallDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// EnsureLocalInstallDir 确保项目级安装目录存在
+func (e *externalProvider) EnsureLocalInstallDir(projectRoot string) (string, error) {
+	dir := e.LocalSkillsDir(projectRoot)
+	if dir == "" {
+		return "", fmt.Errorf("%s does not support project-local skills", e.name)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// scopeRequest 构建带安装范围的请求
+func scopeRequest(op, projectRoot string) externalRequest {
+	if projectRoot == "" {
+		return externalRequest{Op: op, Scope: "global"}
+	}
+	return externalRequest{Op: op, Scope: "project", ProjectRoot: projectRoot}
+}
+
+// InstallSkill 由插件安装 skill
+func (e *externalProvider) InstallSkill(src, name, projectRoot string) (string, error) {
+	req := scopeRequest(opInstall, projectRoot)
+	req.Name, req.Source = name, src
+	resp, err := e.call(req)
+	return resp.Path, err
+}
+
+// RemoveSkill 由插件删除 skill
+func (e *externalProvider) RemoveSkill(name, projectRoot string) error {
+	req := scopeRequest(opRemove, projectRoot)
+	req.Name = name
+	_, err := e.call(req)
+	return err
+}
+
+// ListSkills 由插件列出已安装的 skill
+func (e *externalProvider) ListSkills(projectRoot string) ([]ManagedSkill, error) {
+	resp, err := e.call(scopeRequest(opList, projectRoot))
+	return resp.Skills, err
+}
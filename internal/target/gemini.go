@@ -66,3 +66,12 @@ func (g *geminiProvider) EnsureLocalInstallDir(projectRoot string) (string, erro
 	}
 	return dir, nil
 }
+
+// Detect 检测 Gemini CLI 是否已安装：~/.gemini（不含 Antigravity 子目录）或 gemini 命令
+func (g *geminiProvider) Detect() bool {
+	return detection{
+		configDir: ".gemini",
+		ownDirs:   []string{"skills", "antigravity"},
+		binaries:  []string{"gemini"},
+	}.detect(g.homeDir)
+}
@@ -16,6 +16,7 @@ import (
 // - ./.goose/skills/ — Goose 专用
 // - ./.agents/skills/ — 跨 AI 编码代理的通用目录
 // SkillSync 使用 Goose 专用目录：~/.config/goose/skills/ 和 .goose/skills/
+// ~/.config 遵循 XDG_CONFIG_HOME
 type gooseProvider struct {
 	homeDir string
 }
@@ -39,7 +40,14 @@ func (g *gooseProvider) DisplayName() string {
 // GlobalSkillsDir 返回全局 skills 扫描目录
 // Goose 使用 ~/.config/goose/skills/
 func (g *gooseProvider) GlobalSkillsDir() (string, error) {
-	return filepath.Join(g.homeDir, ".config", "goose", "skills"), nil
+	base, _ := configHome(g.homeDir)
+	return filepath.Join(base, "goose", "skills"), nil
+}
+
+// DirSource 返回全局目录的来源
+func (g *gooseProvider) DirSource() string {
+	_, source := configHome(g.homeDir)
+	return source
 }
 
 // GlobalInstallDir 返回全局安装目录（与扫描目录相同）
@@ -58,6 +66,26 @@ func (g *gooseProvider) Categories() []string {
 	return nil
 }
 
+// SearchPaths 返回 Goose 加载 skill 的目录（按优先级）
+func (g *gooseProvider) SearchPaths(projectRoot string) []SearchPath {
+	var paths []SearchPath
+	if projectRoot != "" {
+		paths = append(paths,
+			SearchPath{Dir: filepath.Join(projectRoot, ".claude", "skills"), Scope: ScopeProject},
+			SearchPath{Dir: g.LocalSkillsDir(projectRoot), Scope: ScopeProject},
+			SearchPath{Dir: filepath.Join(projectRoot, ".agents", "skills"), Scope: ScopeProject},
+		)
+	}
+	base, _ := configHome(g.homeDir)
+	claudeDir, _ := claudeHome(g.homeDir)
+	globalDir, _ := g.GlobalSkillsDir()
+	return append(paths,
+		SearchPath{Dir: filepath.Join(claudeDir, "skills"), Scope: ScopeShared},
+		SearchPath{Dir: filepath.Join(base, "agents", "skills"), Scope: ScopeShared},
+		SearchPath{Dir: globalDir, Scope: ScopeGlobal},
+	)
+}
+
 // EnsureInstallDir 确保全局安装目录存在
 func (g *gooseProvider) EnsureInstallDir() (string, error) {
 	dir, err := g.GlobalInstallDir()
@@ -78,3 +106,13 @@ func (g *gooseProvider) EnsureLocalInstallDir(projectRoot string) (string, error
 	}
 	return dir, nil
 }
+
+// Detect 检测 Goose 是否已安装：$XDG_CONFIG_HOME/goose 或 goose 命令
+func (g *gooseProvider) Detect() bool {
+	base, _ := configHome(g.homeDir)
+	return detection{
+		configDir: filepath.Join(base, "goose"),
+		ownDirs:   []string{"skills"},
+		binaries:  []string{"goose"},
+	}.detect(g.homeDir)
+}
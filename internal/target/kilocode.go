@@ -74,3 +74,12 @@ func (k *kiloCodeProvider) EnsureLocalInstallDir(projectRoot string) (string, er
 	}
 	return dir, nil
 }
+
+// Detect 检测 Kilo Code 是否已安装：~/.kilocode 或 Kilo Code 编辑器扩展
+func (k *kiloCodeProvider) Detect() bool {
+	return detection{
+		configDir:  ".kilocode",
+		ownDirs:    []string{"skills"},
+		extensions: []string{"kilocode.kilo-code"},
+	}.detect(k.homeDir)
+}
@@ -0,0 +1,83 @@
+package target
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// kiroProvider 实现 Kiro 的 ToolProvider 接口
+// Kiro 使用以下目录结构：
+// - 全局 Skills: ~/.kiro/skills/
+// - 项目级 Skills: .kiro/skills/
+// steering 规则位于 .kiro/steering/，SkillSync 仅管理 skills 目录
+type kiroProvider struct {
+	homeDir string
+}
+
+// NewKiroProvider 创建 Kiro Provider 实例
+func NewKiroProvider() ToolProvider {
+	homeDir, _ := os.UserHomeDir()
+	return &kiroProvider{homeDir: homeDir}
+}
+
+// Type 返回工具类型枚举
+func (k *kiroProvider) Type() ToolType {
+	return ToolKiro
+}
+
+// DisplayName 返回用户可见名称
+func (k *kiroProvider) DisplayName() string {
+	return "Kiro IDE"
+}
+
+// GlobalSkillsDir 返回全局 skills 扫描目录
+// Kiro 使用 ~/.kiro/skills/
+func (k *kiroProvider) GlobalSkillsDir() (string, error) {
+	return filepath.Join(k.homeDir, ".kiro", "skills"), nil
+}
+
+// GlobalInstallDir 返回全局安装目录（与扫描目录相同）
+func (k *kiroProvider) GlobalInstallDir() (string, error) {
+	return k.GlobalSkillsDir()
+}
+
+// LocalSkillsDir 返回项目级 skills 目录
+// Kiro 使用 .kiro/skills/
+func (k *kiroProvider) LocalSkillsDir(projectRoot string) string {
+	return filepath.Join(projectRoot, ".kiro", "skills")
+}
+
+// Categories 返回分类子目录列表（Kiro 无分类）
+func (k *kiroProvider) Categories() []string {
+	return nil
+}
+
+// EnsureInstallDir 确保全局安装目录存在
+func (k *kiroProvider) EnsureInstallDir() (string, error) {
+	dir, err := k.GlobalInstallDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// EnsureLocalInstallDir 确保项目级安装目录存在
+func (k *kiroProvider) EnsureLocalInstallDir(projectRoot string) (string, error) {
+	dir := k.LocalSkillsDir(projectRoot)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// Detect 检测 Kiro 是否已安装：~/.kiro、kiro 或 kiro-cli 命令
+func (k *kiroProvider) Detect() bool {
+	return detection{
+		configDir: ".kiro",
+		ownDirs:   []string{"skills"},
+		binaries:  []string{"kiro", "kiro-cli"},
+	}.detect(k.homeDir)
+}
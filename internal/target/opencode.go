@@ -7,8 +7,9 @@ import (
 
 // opencodeProvider 实现 OpenCode 的 ToolProvider 接口
 // OpenCode 使用以下目录结构（注意：使用 skill 单数而非 skills）：
-// - 全局 Skills: ~/.config/opencode/skill/
+// - 全局 Skills: ~/.config/opencode/skill/（遵循 XDG_CONFIG_HOME）
 // - 项目级 Skills: .opencode/skill/
+// 此外兼容加载 Claude Code 的 .claude/skills/ 与 ~/.claude/skills/
 type opencodeProvider struct {
 	homeDir string
 }
@@ -32,7 +33,14 @@ func (o *opencodeProvider) DisplayName() string {
 // GlobalSkillsDir 返回全局 skills 扫描目录
 // OpenCode 使用 ~/.config/opencode/skill/（单数形式）
 func (o *opencodeProvider) GlobalSkillsDir() (string, error) {
-	return filepath.Join(o.homeDir, ".config", "opencode", "skill"), nil
+	base, _ := configHome(o.homeDir)
+	return filepath.Join(base, "opencode", "skill"), nil
+}
+
+// DirSource 返回全局目录的来源
+func (o *opencodeProvider) DirSource() string {
+	_, source := configHome(o.homeDir)
+	return source
 }
 
 // GlobalInstallDir 返回全局安装目录（与扫描目录相同）
@@ -51,6 +59,23 @@ func (o *opencodeProvider) Categories() []string {
 	return nil
 }
 
+// SearchPaths 返回 OpenCode 加载 skill 的目录（按优先级）
+func (o *opencodeProvider) SearchPaths(projectRoot string) []SearchPath {
+	var paths []SearchPath
+	if projectRoot != "" {
+		paths = append(paths,
+			SearchPath{Dir: o.LocalSkillsDir(projectRoot), Scope: ScopeProject},
+			SearchPath{Dir: filepath.Join(projectRoot, ".claude", "skills"), Scope: ScopeProject},
+		)
+	}
+	globalDir, _ := o.GlobalSkillsDir()
+	claudeDir, _ := claudeHome(o.homeDir)
+	return append(paths,
+		SearchPath{Dir: globalDir, Scope: ScopeGlobal},
+		SearchPath{Dir: filepath.Join(claudeDir, "skills"), Scope: ScopeShared},
+	)
+}
+
 // EnsureInstallDir 确保全局安装目录存在
 func (o *opencodeProvider) EnsureInstallDir() (string, error) {
 	dir, err := o.GlobalInstallDir()
@@ -71,3 +96,13 @@ func (o *opencodeProvider) EnsureLocalInstallDir(projectRoot string) (string, er
 	}
 	return dir, nil
 }
+
+// Detect 检测 OpenCode 是否已安装：$XDG_CONFIG_HOME/opencode 或 opencode 命令
+func (o *opencodeProvider) Detect() bool {
+	base, _ := configHome(o.homeDir)
+	return detection{
+		configDir: filepath.Join(base, "opencode"),
+		ownDirs:   []string{"skill"},
+		binaries:  []string{"opencode"},
+	}.detect(o.homeDir)
+}
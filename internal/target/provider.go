@@ -19,6 +19,13 @@ const (
 	ToolKiloCode    ToolType = "kilocode"
 	ToolRooCode     ToolType = "roocode"
 	ToolVSCode      ToolType = "vscode"
+	ToolAmp         ToolType = "amp"
+	ToolQwen        ToolType = "qwen"
+	ToolWindsurf    ToolType = "windsurf"
+	ToolKiro        ToolType = "kiro"
+	ToolTrae        ToolType = "trae"
+	ToolAugment     ToolType = "augment"
+	ToolAgents      ToolType = "agents" // 跨工具通用目录 ~/.config/agents/skills
 )
 
 // String 返回工具类型的字符串表示
@@ -60,3 +67,33 @@ type ToolProvider interface {
 	// projectRoot 为项目根目录
 	EnsureLocalInstallDir(projectRoot string) (string, error)
 }
+
+// RulesProvider 支持以项目规则文件形式安装 skill 的工具（如 Cursor .mdc 规则）
+type RulesProvider interface {
+	ToolProvider
+
+	// LocalRulesDir 返回项目级规则目录路径
+	LocalRulesDir(projectRoot string) string
+}
+
+// ManagedSkill 由工具自行管理的已安装 skill
+type ManagedSkill struct {
+	Name        string `json:"name"`
+	Path        string `json:"path,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// SkillManager 自行处理安装、删除与列举的工具（如外部 provider 插件）
+// projectRoot 为空表示全局范围；返回 ErrUnsupported 时调用方回退为按目录操作
+type SkillManager interface {
+	ToolProvider
+
+	// InstallSkill 安装 src 目录中的 skill，返回安装后的路径（可为空）
+	InstallSkill(src, name, projectRoot string) (string, error)
+
+	// RemoveSkill 删除指定名称的 skill
+	RemoveSkill(name, projectRoot string) error
+
+	// ListSkills 列出已安装的 skill
+	ListSkills(projectRoot string) ([]ManagedSkill, error)
+}
@@ -0,0 +1,82 @@
+package target
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// qwenProvider 实现 Qwen Code 的 ToolProvider 接口
+// Qwen Code 基于 Gemini CLI，使用以下目录结构：
+// - 全局 Skills: ~/.qwen/skills/
+// - 项目级 Skills: .qwen/skills/
+type qwenProvider struct {
+	homeDir string
+}
+
+// NewQwenProvider 创建 Qwen Code Provider 实例
+func NewQwenProvider() ToolProvider {
+	homeDir, _ := os.UserHomeDir()
+	return &qwenProvider{homeDir: homeDir}
+}
+
+// Type 返回工具类型枚举
+func (q *qwenProvider) Type() ToolType {
+	return ToolQwen
+}
+
+// DisplayName 返回用户可见名称
+func (q *qwenProvider) DisplayName() string {
+	return "Qwen Code"
+}
+
+// GlobalSkillsDir 返回全局 skills 扫描目录
+// Qwen Code 使用 ~/.qwen/skills/
+func (q *qwenProvider) GlobalSkillsDir() (string, error) {
+	return filepath.Join(q.homeDir, ".qwen", "skills"), nil
+}
+
+// GlobalInstallDir 返回全局安装目录（与扫描目录相同）
+func (q *qwenProvider) GlobalInstallDir() (string, error) {
+	return q.GlobalSkillsDir()
+}
+
+// LocalSkillsDir 返回项目级 skills 目录
+// Qwen Code 使用 .qwen/skills/
+func (q *qwenProvider) LocalSkillsDir(projectRoot string) string {
+	return filepath.Join(projectRoot, ".qwen", "skills")
+}
+
+// Categories 返回分类子目录列表（Qwen Code 无分类）
+func (q *qwenProvider) Categories() []string {
+	return nil
+}
+
+// EnsureInstallDir 确保全局安装目录存在
+func (q *qwenProvider) EnsureInstallDir() (string, error) {
+	dir, err := q.GlobalInstallDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// EnsureLocalInstallDir 确保项目级安装目录存在
+func (q *qwenProvider) EnsureLocalInstallDir(projectRoot string) (string, error) {
+	dir := q.LocalSkillsDir(projectRoot)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// Detect 检测 Qwen Code 是否已安装：~/.qwen 或 qwen 命令
+func (q *qwenProvider) Detect() bool {
+	return detection{
+		configDir: ".qwen",
+		ownDirs:   []string{"skills"},
+		binaries:  []string{"qwen"},
+	}.detect(q.homeDir)
+}
@@ -2,54 +2,157 @@ package target
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 )
 
+// 工具分类
+const (
+	CategoryTerminal = "terminal" // 终端工具
+	CategoryIDE      = "ide"      // IDE 与编辑器扩展
+	CategoryShared   = "shared"   // 跨工具通用目录
+	CategoryCustom   = "custom"   // 配置文件或插件注册的工具
+)
+
+// ToolInfo 工具元数据，用于生成帮助文本、补全、拼写建议与文档
+type ToolInfo struct {
+	Type        ToolType
+	Aliases     []string // --target 可用的别名
+	Category    string   // CategoryTerminal、CategoryIDE、CategoryShared 或 CategoryCustom
+	Description string   // 一句话说明
+}
+
+// builtinTool 内置工具的元数据与构造函数
+type builtinTool struct {
+	ToolInfo
+	newProvider func() ToolProvider
+}
+
+// builtinTools 内置工具（有序）：终端工具优先，IDE 工具在后，跨工具通用目录排在最后
+var builtinTools = []builtinTool{
+	// 终端工具
+	{ToolInfo{ToolGemini, []string{"gemini-cli"}, CategoryTerminal, "Google Gemini CLI"}, NewGeminiProvider},
+	{ToolInfo{ToolClaude, []string{"claude-code"}, CategoryTerminal, "Anthropic Claude Code"}, NewClaudeProvider},
+	{ToolInfo{ToolCodex, []string{"codex-cli"}, CategoryTerminal, "OpenAI Codex CLI"}, NewCodexProvider},
+	{ToolInfo{ToolOpencode, []string{"open-code"}, CategoryTerminal, "OpenCode terminal agent"}, NewOpencodeProvider},
+	{ToolInfo{ToolGoose, nil, CategoryTerminal, "Block Goose agent"}, NewGooseProvider},
+	{ToolInfo{ToolCrush, nil, CategoryTerminal, "Charm Crush terminal assistant"}, NewCrushProvider},
+	{ToolInfo{ToolAmp, nil, CategoryTerminal, "Sourcegraph Amp agent"}, NewAmpProvider},
+	{ToolInfo{ToolQwen, []string{"qwen-code"}, CategoryTerminal, "Qwen Code terminal agent"}, NewQwenProvider},
+	// IDE 工具
+	{ToolInfo{ToolAntigravity, nil, CategoryIDE, "Google Antigravity IDE"}, NewAntigravityProvider},
+	{ToolInfo{ToolCopilot, []string{"github-copilot"}, CategoryIDE, "GitHub Copilot agent and CLI"}, NewCopilotProvider},
+	{ToolInfo{ToolCursor, nil, CategoryIDE, "Cursor editor"}, NewCursorProvider},
+	{ToolInfo{ToolCline, nil, CategoryIDE, "Cline VSCode extension"}, NewClineProvider},
+	{ToolInfo{ToolDroid, []string{"factory"}, CategoryIDE, "Factory AI Droid"}, NewDroidProvider},
+	{ToolInfo{ToolKiloCode, []string{"kilo", "kilo-code"}, CategoryIDE, "Kilo Code VSCode extension"}, NewKiloCodeProvider},
+	{ToolInfo{ToolRooCode, []string{"roo", "roo-code"}, CategoryIDE, "Roo Code VSCode extension"}, NewRooCodeProvider},
+	{ToolInfo{ToolVSCode, []string{"code"}, CategoryIDE, "VSCode agent mode (same directories as Copilot)"}, NewVSCodeProvider},
+	{ToolInfo{ToolWindsurf, nil, CategoryIDE, "Windsurf editor (Cascade)"}, NewWindsurfProvider},
+	{ToolInfo{ToolKiro, nil, CategoryIDE, "AWS Kiro IDE"}, NewKiroProvider},
+	{ToolInfo{ToolTrae, nil, CategoryIDE, "ByteDance Trae IDE"}, NewTraeProvider},
+	{ToolInfo{ToolAugment, []string{"auggie"}, CategoryIDE, "Augment Code extension and Auggie CLI"}, NewAugmentProvider},
+	// 通用目录
+	{ToolInfo{ToolAgents, []string{"shared"}, CategoryShared, "Cross-agent directory ~/.config/agents/skills"}, NewAgentsProvider},
+}
+
 // registry 存储所有已注册的 Provider
 var (
 	providers     map[ToolType]ToolProvider
 	providersOnce sync.Once
+	customTypes   []ToolType // 通过 Register 注册的工具（按注册顺序）
 )
 
 // initProviders 初始化 Provider 注册表（懒加载）
 func initProviders() {
 	providersOnce.Do(func() {
-		providers = map[ToolType]ToolProvider{
-			ToolGemini:      NewGeminiProvider(),
-			ToolClaude:      NewClaudeProvider(),
-			ToolCodex:       NewCodexProvider(),
-			ToolAntigravity: NewAntigravityProvider(),
-			ToolCopilot:     NewCopilotProvider(),
-			ToolCursor:      NewCursorProvider(),
-			ToolOpencode:    NewOpencodeProvider(),
-			ToolCline:       NewClineProvider(),
-			ToolGoose:       NewGooseProvider(),
-			ToolCrush:       NewCrushProvider(),
-			ToolDroid:       NewDroidProvider(),
-			ToolKiloCode:    NewKiloCodeProvider(),
-			ToolRooCode:     NewRooCodeProvider(),
-			ToolVSCode:      NewVSCodeProvider(),
+		providers = make(map[ToolType]ToolProvider, len(builtinTools))
+		for _, t := range builtinTools {
+			providers[t.Type] = t.newProvider()
 		}
 	})
 }
 
 // AllToolTypes 返回所有支持的工具类型（有序）
-// 终端工具优先，IDE 工具在后
+// 终端工具优先，IDE 工具在后，跨工具通用目录排在最后
 func AllToolTypes() []ToolType {
-	return []ToolType{
-		// 终端工具
-		ToolGemini, ToolClaude, ToolCodex, ToolOpencode, ToolGoose, ToolCrush,
-		// IDE 工具
-		ToolAntigravity, ToolCopilot, ToolCursor, ToolCline, ToolDroid, ToolKiloCode, ToolRooCode, ToolVSCode,
+	types := make([]ToolType, len(builtinTools))
+	for i, t := range builtinTools {
+		types[i] = t.Type
+	}
+	return types
+}
+
+// Tools 返回内置工具与已注册自定义工具的元数据（有序）
+func Tools() []ToolInfo {
+	initProviders()
+	infos := make([]ToolInfo, 0, len(builtinTools)+len(customTypes))
+	for _, t := range builtinTools {
+		infos = append(infos, t.ToolInfo)
+	}
+	for _, t := range customTypes {
+		infos = append(infos, ToolInfo{Type: t, Category: CategoryCustom, Description: providers[t].DisplayName()})
+	}
+	return infos
+}
+
+// ToolsInCategory 返回指定分类的内置工具元数据
+func ToolsInCategory(category string) []ToolInfo {
+	var infos []ToolInfo
+	for _, t := range builtinTools {
+		if t.Category == category {
+			infos = append(infos, t.ToolInfo)
+		}
+	}
+	return infos
+}
+
+// ToolNames 返回 --target 可用的工具标识（不含别名）
+func ToolNames() []string {
+	var names []string
+	for _, t := range Tools() {
+		names = append(names, t.Type.String())
+	}
+	return names
+}
+
+// resolveAlias 将别名解析为工具类型，不是别名时原样返回
+func resolveAlias(name string) ToolType {
+	for _, t := range builtinTools {
+		if slices.Contains(t.Aliases, name) {
+			return t.Type
+		}
 	}
+	return ToolType(name)
+}
+
+// allTypes 返回内置工具与已注册的自定义工具类型
+func allTypes() []ToolType {
+	return append(AllToolTypes(), customTypes...)
+}
+
+// Register 注册额外的 Provider（如配置文件中声明的自定义工具）
+// 与已注册工具类型冲突时返回错误
+func Register(p ToolProvider) error {
+	initProviders()
+	if _, ok := providers[p.Type()]; ok {
+		return fmt.Errorf("target %s is already registered", p.Type())
+	}
+	if err := checkReservedID(p.Type().String()); err != nil {
+		return err
+	}
+	providers[p.Type()] = p
+	customTypes = append(customTypes, p.Type())
+	return nil
 }
 
 // AllProviders 返回所有已注册的 Provider 列表
 func AllProviders() []ToolProvider {
 	initProviders()
 	result := make([]ToolProvider, 0, len(providers))
-	// 保持固定顺序
-	for _, t := range AllToolTypes() {
+	// 保持固定顺序，自定义工具排在内置工具之后
+	for _, t := range allTypes() {
 		if p, ok := providers[t]; ok {
 			result = append(result, p)
 		}
@@ -58,33 +161,81 @@ func AllProviders() []ToolProvider {
 }
 
 // GetProvider 根据工具类型获取对应的 Provider
+// 未知类型时给出拼写最接近的建议
 func GetProvider(toolType ToolType) (ToolProvider, error) {
 	initProviders()
 	if p, ok := providers[toolType]; ok {
 		return p, nil
 	}
-	return nil, fmt.Errorf("unknown provider: %s, valid providers are: gemini, claude, codex, opencode, goose, crush, antigravity, copilot, cursor, cline, droid, kilocode, roocode, vscode", toolType)
+	names := ToolNames()
+	if suggestion := Suggest(toolType.String()); suggestion != "" {
+		return nil, fmt.Errorf("unknown provider: %s, did you mean %s? valid providers are: %s", toolType, suggestion, strings.Join(names, ", "))
+	}
+	return nil, fmt.Errorf("unknown provider: %s, valid providers are: %s", toolType, strings.Join(names, ", "))
 }
 
-// GetProviderByName 根据名称字符串获取对应的 Provider
+// GetProviderByName 根据名称字符串（工具标识或别名，不区分大小写）获取对应的 Provider
 func GetProviderByName(name string) (ToolProvider, error) {
-	return GetProvider(ToolType(name))
+	return GetProvider(resolveAlias(strings.ToLower(strings.TrimSpace(name))))
+}
+
+// TargetDetected --target 取值，表示所有检测到已安装的工具
+const TargetDetected = "detected"
+
+// TargetAll --target 取值，表示所有注册的工具（含自定义工具与外部插件）
+const TargetAll = "all"
+
+// reservedIDs --target 的保留取值，不能用作注册工具的标识
+var reservedIDs = []string{TargetDetected, TargetAll}
+
+// checkReservedID 检查工具标识是否为保留取值或内置工具的别名
+func checkReservedID(id string) error {
+	if slices.Contains(reservedIDs, id) {
+		return fmt.Errorf("target id %q is reserved", id)
+	}
+	if t := resolveAlias(id); t != ToolType(id) {
+		return fmt.Errorf("target id %q is an alias of %s", id, t)
+	}
+	return nil
 }
 
 // ParseProviders 解析 Provider 名称列表，返回对应的 Provider 切片
-// 如果输入为空，返回所有 Provider
+// 如果输入为空或包含 all，返回所有 Provider；detected 展开为检测到已安装的工具
 func ParseProviders(names []string) ([]ToolProvider, error) {
 	if len(names) == 0 {
 		return AllProviders(), nil
 	}
 
 	result := make([]ToolProvider, 0, len(names))
+	seen := make(map[ToolType]bool)
+	add := func(p ToolProvider) {
+		if !seen[p.Type()] {
+			seen[p.Type()] = true
+			result = append(result, p)
+		}
+	}
 	for _, name := range names {
+		if name == TargetAll {
+			return AllProviders(), nil
+		}
+	}
+
+	for _, name := range names {
+		if name == TargetDetected {
+			detected := DetectedProviders()
+			if len(detected) == 0 {
+				return nil, fmt.Errorf("no installed tools detected, specify --target explicitly")
+			}
+			for _, p := range detected {
+				add(p)
+			}
+			continue
+		}
 		p, err := GetProviderByName(name)
 		if err != nil {
 			return nil, err
 		}
-		result = append(result, p)
+		add(p)
 	}
 	return result, nil
 }
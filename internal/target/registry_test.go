@@ -0,0 +1,16 @@
+package target
+
+import "testing"
+
+func TestParseProvidersAll(t *testing.T) {
+	all := AllProviders()
+	for _, names := range [][]string{nil, {TargetAll}, {"claude", TargetAll}} {
+		got, err := ParseProviders(names)
+		if err != nil {
+			t.Fatalf("ParseProviders(%v) error = %v", names, err)
+		}
+		if len(got) != len(all) {
+			t.Errorf("ParseProviders(%v) returned %d providers, want %d", names, len(got), len(all))
+		}
+	}
+}
@@ -74,3 +74,12 @@ func (r *rooCodeProvider) EnsureLocalInstallDir(projectRoot string) (string, err
 	}
 	return dir, nil
 }
+
+// Detect 检测 Roo Code 是否已安装：~/.roo 或 Roo Code 编辑器扩展
+func (r *rooCodeProvider) Detect() bool {
+	return detection{
+		configDir:  ".roo",
+		ownDirs:    []string{"skills"},
+		extensions: []string{"rooveterinaryinc.roo-cline"},
+	}.detect(r.homeDir)
+}
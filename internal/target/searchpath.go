@@ -0,0 +1,39 @@
+package target
+
+// 搜索路径范围
+const (
+	ScopeProject = "project" // 项目级目录
+	ScopeShared  = "shared"  // 多个工具共用的全局目录（如 ~/.claude/skills、~/.config/agents/skills）
+	ScopeGlobal  = "global"  // 工具专用的全局目录
+)
+
+// SearchPath 工具加载 skill 时搜索的一个目录
+type SearchPath struct {
+	Dir   string
+	Scope string // ScopeProject、ScopeShared 或 ScopeGlobal
+}
+
+// SearchPathProvider 从多个目录加载 skill 的工具（如 Goose、OpenCode 也读取 ~/.claude/skills）
+type SearchPathProvider interface {
+	// SearchPaths 按优先级从高到低返回搜索目录，同名 skill 以先找到的为准
+	// projectRoot 为空时仅返回全局目录
+	SearchPaths(projectRoot string) []SearchPath
+}
+
+// SearchPaths 返回工具的搜索路径
+// 未实现 SearchPathProvider 的工具依次搜索项目级目录与全局目录
+func SearchPaths(p ToolProvider, projectRoot string) []SearchPath {
+	if sp, ok := p.(SearchPathProvider); ok {
+		return sp.SearchPaths(projectRoot)
+	}
+	var paths []SearchPath
+	if projectRoot != "" {
+		if dir := p.LocalSkillsDir(projectRoot); dir != "" {
+			paths = append(paths, SearchPath{Dir: dir, Scope: ScopeProject})
+		}
+	}
+	if dir, err := p.GlobalSkillsDir(); err == nil {
+		paths = append(paths, SearchPath{Dir: dir, Scope: ScopeGlobal})
+	}
+	return paths
+}
@@ -0,0 +1,35 @@
+package target
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSearchPathsFollowClaudeConfigDir(t *testing.T) {
+	home := t.TempDir()
+	claudeDir := filepath.Join(t.TempDir(), "claude")
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("CLAUDE_CONFIG_DIR", claudeDir)
+
+	want := filepath.Join(claudeDir, "skills")
+	if dir, _ := NewClaudeProvider().GlobalSkillsDir(); dir != want {
+		t.Fatalf("Claude GlobalSkillsDir() = %q, want %q", dir, want)
+	}
+
+	for _, p := range []ToolProvider{NewGooseProvider(), NewOpencodeProvider(), NewAmpProvider()} {
+		t.Run(p.DisplayName(), func(t *testing.T) {
+			var dirs []string
+			for _, sp := range SearchPaths(p, "") {
+				dirs = append(dirs, sp.Dir)
+			}
+			if !slices.Contains(dirs, want) {
+				t.Errorf("SearchPaths() = %v, want it to contain %q", dirs, want)
+			}
+			if stale := filepath.Join(home, ".claude", "skills"); slices.Contains(dirs, stale) {
+				t.Errorf("SearchPaths() = %v, should not contain %q", dirs, stale)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package target
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// ShortNamer 提供与其他工具合并显示时使用的简短名称
+// 如 Copilot 与 VSCode 共享目录，合并显示为 "Copilot / VSCode"
+type ShortNamer interface {
+	ShortName() string
+}
+
+// sharedProvider 共享同一组 skills 目录的多个工具
+// 目录与分类沿用第一个工具，安装、列出、删除只执行一次
+type sharedProvider struct {
+	members []ToolProvider
+}
+
+// GroupByDir 合并解析后目录相同的工具（全局扫描目录、全局安装目录、项目级目录均相同）
+// 合并后的工具位于第一个成员的位置，仅有一个成员时原样返回
+// 项目级目录按 projectRoot 解析，为空时仅比较全局目录
+func GroupByDir(providers []ToolProvider, projectRoot string) []ToolProvider {
+	var keys []string
+	groups := make(map[string][]ToolProvider)
+	for _, p := range providers {
+		key, ok := dirKey(p, projectRoot)
+		if !ok {
+			// 目录无法解析的工具不参与合并
+			key = "type:" + p.Type().String()
+		}
+		if _, exists := groups[key]; !exists {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], p)
+	}
+
+	result := make([]ToolProvider, 0, len(keys))
+	for _, key := range keys {
+		members := groups[key]
+		if len(members) == 1 {
+			result = append(result, members[0])
+			continue
+		}
+		result = append(result, &sharedProvider{members: members})
+	}
+	return result
+}
+
+// Members 返回合并工具的各成员，普通工具返回自身
+func Members(p ToolProvider) []ToolProvider {
+	if s, ok := p.(*sharedProvider); ok {
+		return s.members
+	}
+	return []ToolProvider{p}
+}
+
+// ManagesSkills 判断工具（或合并工具的任一成员）是否自行管理安装（如外部插件）
+func ManagesSkills(p ToolProvider) bool {
+	for _, m := range Members(p) {
+		if _, ok := m.(SkillManager); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// dirKey 返回工具各目录解析符号链接后的组合键
+func dirKey(p ToolProvider, projectRoot string) (string, bool) {
+	skillsDir, err := p.GlobalSkillsDir()
+	if err != nil {
+		return "", false
+	}
+	installDir, err := p.GlobalInstallDir()
+	if err != nil {
+		return "", false
+	}
+	localDir := ""
+	if projectRoot != "" {
+		localDir = p.LocalSkillsDir(projectRoot)
+	}
+	return strings.Join([]string{resolveDir(skillsDir), resolveDir(installDir), resolveDir(localDir)}, "\x00"), true
+}
+
+// resolveDir 规范化目录路径，目录存在时解析符号链接
+func resolveDir(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	dir = filepath.Clean(dir)
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		return resolved
+	}
+	return dir
+}
+
+// shortName 返回工具的简短名称，未实现 ShortNamer 时使用 DisplayName
+func shortName(p ToolProvider) string {
+	if s, ok := p.(ShortNamer); ok {
+		return s.ShortName()
+	}
+	return p.DisplayName()
+}
+
+// Type 返回第一个成员的工具类型
+func (s *sharedProvider) Type() ToolType {
+	return s.members[0].Type()
+}
+
+// DisplayName 返回合并后的名称，如 "Copilot / VSCode"
+func (s *sharedProvider) DisplayName() string {
+	names := make([]string, len(s.members))
+	for i, m := range s.members {
+		names[i] = shortName(m)
+	}
+	return strings.Join(names, " / ")
+}
+
+// GlobalSkillsDir 返回共享的全局 skills 扫描目录
+func (s *sharedProvider) GlobalSkillsDir() (string, error) {
+	return s.members[0].GlobalSkillsDir()
+}
+
+// GlobalInstallDir 返回共享的全局安装目录
+func (s *sharedProvider) GlobalInstallDir() (string, error) {
+	return s.members[0].GlobalInstallDir()
+}
+
+// LocalSkillsDir 返回共享的项目级 skills 目录
+func (s *sharedProvider) LocalSkillsDir(projectRoot string) string {
+	return s.members[0].LocalSkillsDir(projectRoot)
+}
+
+// Categories 返回第一个成员的分类子目录列表
+func (s *sharedProvider) Categories() []string {
+	return s.members[0].Categories()
+}
+
+// ProtectedCategories 返回第一个成员的只读分类
+func (s *sharedProvider) ProtectedCategories() []string {
+	if cp, ok := s.members[0].(CategoryProtector); ok {
+		return cp.ProtectedCategories()
+	}
+	return nil
+}
+
+// SearchPaths 返回第一个成员的搜索路径
+func (s *sharedProvider) SearchPaths(projectRoot string) []SearchPath {
+	return SearchPaths(s.members[0], projectRoot)
+}
+
+// DirSource 返回第一个成员全局目录的来源
+func (s *sharedProvider) DirSource() string {
+	return DirSource(s.members[0])
+}
+
+// EnsureInstallDir 确保共享的全局安装目录存在
+func (s *sharedProvider) EnsureInstallDir() (string, error) {
+	return s.members[0].EnsureInstallDir()
+}
+
+// EnsureLocalInstallDir 确保共享的项目级安装目录存在
+func (s *sharedProvider) EnsureLocalInstallDir(projectRoot string) (string, error) {
+	return s.members[0].EnsureLocalInstallDir(projectRoot)
+}
+
+// Detect 任一成员已安装即视为已安装
+func (s *sharedProvider) Detect() bool {
+	for _, m := range s.members {
+		if IsDetected(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// manager 返回第一个自行管理安装的成员
+func (s *sharedProvider) manager() (SkillManager, bool) {
+	for _, m := range s.members {
+		if sm, ok := m.(SkillManager); ok {
+			return sm, true
+		}
+	}
+	return nil, false
+}
+
+// InstallSkill 由自行管理安装的成员安装，没有时回退为目录拷贝
+func (s *sharedProvider) InstallSkill(src, name, projectRoot string) (string, error) {
+	if sm, ok := s.manager(); ok {
+		return sm.InstallSkill(src, name, projectRoot)
+	}
+	return "", ErrUnsupported
+}
+
+// RemoveSkill 由自行管理安装的成员删除，没有时回退为按目录删除
+func (s *sharedProvider) RemoveSkill(name, projectRoot string) error {
+	if sm, ok := s.manager(); ok {
+		return sm.RemoveSkill(name, projectRoot)
+	}
+	return ErrUnsupported
+}
+
+// ListSkills 由自行管理安装的成员列出，没有时回退为扫描目录
+func (s *sharedProvider) ListSkills(projectRoot string) ([]ManagedSkill, error) {
+	if sm, ok := s.manager(); ok {
+		return sm.ListSkills(projectRoot)
+	}
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,83 @@
+package target
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// newTestProvider 创建使用指定目录的自定义工具
+func newTestProvider(t *testing.T, id, globalDir, localDir string) ToolProvider {
+	t.Helper()
+	p, err := NewCustomProvider(CustomSpec{ID: id, GlobalDir: globalDir, LocalDir: localDir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestGroupByDir(t *testing.T) {
+	home := t.TempDir()
+	shared := filepath.Join(home, "shared")
+	a := newTestProvider(t, "tool-a", shared, ".a/skills")
+	b := newTestProvider(t, "tool-b", shared, ".b/skills")
+	c := newTestProvider(t, "tool-c", shared, ".a/skills")
+	d := newTestProvider(t, "tool-d", filepath.Join(home, "other"), ".a/skills")
+	providers := []ToolProvider{a, b, c, d}
+
+	tests := []struct {
+		name        string
+		projectRoot string
+		want        [][]ToolType
+	}{
+		{
+			name: "without project only global dirs are compared",
+			want: [][]ToolType{{"tool-a", "tool-b", "tool-c"}, {"tool-d"}},
+		},
+		{
+			name:        "project dirs must match inside a project",
+			projectRoot: t.TempDir(),
+			want:        [][]ToolType{{"tool-a", "tool-c"}, {"tool-b"}, {"tool-d"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := GroupByDir(providers, tt.projectRoot)
+			if len(groups) != len(tt.want) {
+				t.Fatalf("GroupByDir() returned %d groups, want %d", len(groups), len(tt.want))
+			}
+			for i, g := range groups {
+				members := Members(g)
+				if len(members) != len(tt.want[i]) {
+					t.Fatalf("group %d has %d members, want %v", i, len(members), tt.want[i])
+				}
+				for j, m := range members {
+					if m.Type() != tt.want[i][j] {
+						t.Errorf("group %d member %d = %s, want %s", i, j, m.Type(), tt.want[i][j])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestManagesSkills(t *testing.T) {
+	plain := newTestProvider(t, "tool-a", t.TempDir(), "")
+	plugin := NewExternalProvider("plugin", "/nonexistent/skillsync-provider-plugin")
+
+	tests := []struct {
+		name string
+		p    ToolProvider
+		want bool
+	}{
+		{"directory provider", plain, false},
+		{"plugin provider", plugin, true},
+		{"merged without plugin", &sharedProvider{members: []ToolProvider{plain, plain}}, false},
+		{"merged with plugin", &sharedProvider{members: []ToolProvider{plain, plugin}}, true},
+	}
+	for _, tt := range tests {
+		if got := ManagesSkills(tt.p); got != tt.want {
+			t.Errorf("%s: ManagesSkills() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package target
+
+// maxSuggestDistance 拼写建议允许的最大编辑距离
+const maxSuggestDistance = 2
+
+// Suggest 返回与 name 拼写最接近的工具标识或别名，没有足够接近的候选时返回空
+func Suggest(name string) string {
+	var candidates []string
+	for _, t := range Tools() {
+		candidates = append(candidates, t.Type.String())
+		candidates = append(candidates, t.Aliases...)
+	}
+	candidates = append(candidates, TargetDetected, TargetAll)
+
+	// 短名称只允许更小的编辑距离，避免给出无关建议
+	best, bestDist := "", min(maxSuggestDistance, len(name)/2)+1
+	for _, c := range candidates {
+		if d := editDistance(name, c); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// editDistance 计算两个字符串的 Levenshtein 编辑距离
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
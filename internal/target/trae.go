@@ -0,0 +1,83 @@
+package target
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// traeProvider 实现 Trae 的 ToolProvider 接口
+// Trae 使用以下目录结构：
+// - 全局 Skills: ~/.trae/skills/
+// - 项目级 Skills: .trae/skills/
+// 规则文件位于 .trae/rules/，SkillSync 仅管理 skills 目录
+type traeProvider struct {
+	homeDir string
+}
+
+// NewTraeProvider 创建 Trae Provider 实例
+func NewTraeProvider() ToolProvider {
+	homeDir, _ := os.UserHomeDir()
+	return &traeProvider{homeDir: homeDir}
+}
+
+// Type 返回工具类型枚举
+func (t *traeProvider) Type() ToolType {
+	return ToolTrae
+}
+
+// DisplayName 返回用户可见名称
+func (t *traeProvider) DisplayName() string {
+	return "Trae IDE"
+}
+
+// GlobalSkillsDir 返回全局 skills 扫描目录
+// Trae 使用 ~/.trae/skills/
+func (t *traeProvider) GlobalSkillsDir() (string, error) {
+	return filepath.Join(t.homeDir, ".trae", "skills"), nil
+}
+
+// GlobalInstallDir 返回全局安装目录（与扫描目录相同）
+func (t *traeProvider) GlobalInstallDir() (string, error) {
+	return t.GlobalSkillsDir()
+}
+
+// LocalSkillsDir 返回项目级 skills 目录
+// Trae 使用 .trae/skills/
+func (t *traeProvider) LocalSkillsDir(projectRoot string) string {
+	return filepath.Join(projectRoot, ".trae", "skills")
+}
+
+// Categories 返回分类子目录列表（Trae 无分类）
+func (t *traeProvider) Categories() []string {
+	return nil
+}
+
+// EnsureInstallDir 确保全局安装目录存在
+func (t *traeProvider) EnsureInstallDir() (string, error) {
+	dir, err := t.GlobalInstallDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// EnsureLocalInstallDir 确保项目级安装目录存在
+func (t *traeProvider) EnsureLocalInstallDir(projectRoot string) (string, error) {
+	dir := t.LocalSkillsDir(projectRoot)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// Detect 检测 Trae 是否已安装：~/.trae 或 trae 命令
+func (t *traeProvider) Detect() bool {
+	return detection{
+		configDir: ".trae",
+		ownDirs:   []string{"skills"},
+		binaries:  []string{"trae"},
+	}.detect(t.homeDir)
+}
@@ -25,3 +25,16 @@ func (v *vscodeProvider) Type() ToolType {
 func (v *vscodeProvider) DisplayName() string {
 	return "VSCode (Copilot)"
 }
+
+// ShortName 返回与 Copilot 合并显示时的简短名称（覆盖基类方法）
+func (v *vscodeProvider) ShortName() string {
+	return "VSCode"
+}
+
+// Detect 检测 VSCode 是否已安装（覆盖基类方法）
+func (v *vscodeProvider) Detect() bool {
+	return detection{
+		configDir: ".vscode",
+		binaries:  []string{"code", "code-insiders"},
+	}.detect(v.homeDir)
+}
@@ -0,0 +1,83 @@
+package target
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// windsurfProvider 实现 Windsurf 的 ToolProvider 接口
+// Windsurf（Codeium）的 Cascade 使用以下目录结构：
+// - 全局 Skills: ~/.codeium/windsurf/skills/
+// - 项目级 Skills: .windsurf/skills/
+// 规则文件位于 .windsurf/rules/，SkillSync 仅管理 skills 目录
+type windsurfProvider struct {
+	homeDir string
+}
+
+// NewWindsurfProvider 创建 Windsurf Provider 实例
+func NewWindsurfProvider() ToolProvider {
+	homeDir, _ := os.UserHomeDir()
+	return &windsurfProvider{homeDir: homeDir}
+}
+
+// Type 返回工具类型枚举
+func (w *windsurfProvider) Type() ToolType {
+	return ToolWindsurf
+}
+
+// DisplayName 返回用户可见名称
+func (w *windsurfProvider) DisplayName() string {
+	return "Windsurf IDE"
+}
+
+// GlobalSkillsDir 返回全局 skills 扫描目录
+// Windsurf 使用 ~/.codeium/windsurf/skills/
+func (w *windsurfProvider) GlobalSkillsDir() (string, error) {
+	return filepath.Join(w.homeDir, ".codeium", "windsurf", "skills"), nil
+}
+
+// GlobalInstallDir 返回全局安装目录（与扫描目录相同）
+func (w *windsurfProvider) GlobalInstallDir() (string, error) {
+	return w.GlobalSkillsDir()
+}
+
+// LocalSkillsDir 返回项目级 skills 目录
+// Windsurf 使用 .windsurf/skills/
+func (w *windsurfProvider) LocalSkillsDir(projectRoot string) string {
+	return filepath.Join(projectRoot, ".windsurf", "skills")
+}
+
+// Categories 返回分类子目录列表（Windsurf 无分类）
+func (w *windsurfProvider) Categories() []string {
+	return nil
+}
+
+// EnsureInstallDir 确保全局安装目录存在
+func (w *windsurfProvider) EnsureInstallDir() (string, error) {
+	dir, err := w.GlobalInstallDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// EnsureLocalInstallDir 确保项目级安装目录存在
+func (w *windsurfProvider) EnsureLocalInstallDir(projectRoot string) (string, error) {
+	dir := w.LocalSkillsDir(projectRoot)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// Detect 检测 Windsurf 是否已安装：~/.codeium/windsurf 或 windsurf 命令
+func (w *windsurfProvider) Detect() bool {
+	return detection{
+		configDir: ".codeium/windsurf",
+		ownDirs:   []string{"skills"},
+		binaries:  []string{"windsurf"},
+	}.detect(w.homeDir)
+}
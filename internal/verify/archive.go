@@ -0,0 +1,184 @@
+package verify
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// SignatureSuffix 分离签名文件后缀，写在归档文件旁
+const SignatureSuffix = ".sig"
+
+// sshNamespace SSH 签名使用的命名空间，防止签名被挪用到其他用途
+const sshNamespace = "skillsync"
+
+// sshSignatureHeader SSH 签名文件的 PEM 头
+const sshSignatureHeader = "-----BEGIN SSH SIGNATURE-----"
+
+// SignArchive 为归档生成分离签名 <archive>.sig
+// keyFile 支持 PKCS#8 PEM 格式的 ed25519 私钥，或 OpenSSH 私钥（调用 ssh-keygen -Y sign）
+// 返回: 签名文件路径与签名方式
+func SignArchive(archive, keyFile string) (string, string, error) {
+	keyData, err := os.ReadFile(keyFile)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read signing key: %w", err)
+	}
+	sigPath := archive + SignatureSuffix
+
+	if block, _ := pem.Decode(keyData); block != nil && block.Type == "PRIVATE KEY" {
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid signing key: %w", err)
+		}
+		priv, ok := parsed.(ed25519.PrivateKey)
+		if !ok {
+			return "", "", fmt.Errorf("signing key is not an ed25519 key")
+		}
+		content, err := os.ReadFile(archive)
+		if err != nil {
+			return "", "", err
+		}
+		sig := base64.StdEncoding.EncodeToString(ed25519.Sign(priv, content))
+		if err := os.WriteFile(sigPath, []byte(sig+"\n"), 0644); err != nil {
+			return "", "", fmt.Errorf("failed to write signature: %w", err)
+		}
+		return sigPath, "ed25519", nil
+	}
+
+	// OpenSSH 私钥：交给 ssh-keygen 处理（支持 ssh-agent 与加密私钥）
+	os.Remove(sigPath)
+	var out bytes.Buffer
+	cmd := exec.Command("ssh-keygen", "-Y", "sign", "-f", keyFile, "-n", sshNamespace, archive)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	cmd.Stdin = os.Stdin
+	if err := cmd.Run(); err != nil {
+		return "", "", fmt.Errorf("ssh-keygen sign failed: %s", firstLine(out.String()))
+	}
+	return sigPath, "ssh", nil
+}
+
+// VerifyArchive 校验归档的分离签名
+// SSH 签名使用 allowedSigners 校验；ed25519 签名依次尝试 publicKeys 中的公钥
+func VerifyArchive(archive, sigPath, allowedSigners string, publicKeys []string) Result {
+	sigData, err := os.ReadFile(sigPath)
+	if os.IsNotExist(err) {
+		return Result{Status: StatusUnsigned, Detail: "no signature file " + sigPath}
+	}
+	if err != nil {
+		return Result{Status: StatusInvalid, Detail: err.Error()}
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(string(sigData)), sshSignatureHeader) {
+		return verifySSH(archive, sigPath, allowedSigners)
+	}
+	return verifyEd25519(archive, sigData, publicKeys)
+}
+
+// verifySSH 使用 ssh-keygen 校验 SSH 签名
+func verifySSH(archive, sigPath, allowedSigners string) Result {
+	result := Result{Method: "ssh"}
+	if allowedSigners == "" || !fileExists(allowedSigners) {
+		result.Status = StatusInvalid
+		result.Detail = "no allowed signers file configured (verify.allowed-signers)"
+		return result
+	}
+
+	// 关键步骤：先找出签名对应的受信任身份
+	principalOut, err := exec.Command("ssh-keygen", "-Y", "find-principals", "-s", sigPath, "-f", allowedSigners).CombinedOutput()
+	principal := firstLine(string(principalOut))
+	if err != nil || principal == "" {
+		result.Status = StatusInvalid
+		result.Detail = "signer is not in allowed signers"
+		return result
+	}
+
+	archiveFile, err := os.Open(archive)
+	if err != nil {
+		result.Status = StatusInvalid
+		result.Detail = err.Error()
+		return result
+	}
+	defer archiveFile.Close()
+
+	var out bytes.Buffer
+	cmd := exec.Command("ssh-keygen", "-Y", "verify", "-f", allowedSigners, "-I", principal, "-n", sshNamespace, "-s", sigPath)
+	cmd.Stdin = archiveFile
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		result.Status = StatusInvalid
+		result.Detail = firstLine(out.String())
+		return result
+	}
+
+	result.Status = StatusVerified
+	result.Signer = parseSigner(out.String())
+	return result
+}
+
+// verifyEd25519 使用配置的 ed25519 公钥校验签名
+func verifyEd25519(archive string, sigData []byte, publicKeys []string) Result {
+	result := Result{Method: "ed25519"}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sigData)))
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		result.Status = StatusInvalid
+		result.Detail = "malformed ed25519 signature"
+		return result
+	}
+	if len(publicKeys) == 0 {
+		result.Status = StatusInvalid
+		result.Detail = "no public keys configured (verify.public-keys)"
+		return result
+	}
+
+	content, err := os.ReadFile(archive)
+	if err != nil {
+		result.Status = StatusInvalid
+		result.Detail = err.Error()
+		return result
+	}
+
+	for _, keyFile := range publicKeys {
+		pub, err := readEd25519PublicKey(keyFile)
+		if err != nil {
+			continue
+		}
+		if ed25519.Verify(pub, content, sig) {
+			result.Status = StatusVerified
+			result.Signer = keyFile
+			return result
+		}
+	}
+
+	result.Status = StatusInvalid
+	result.Detail = "signature does not match any configured public key"
+	return result
+}
+
+// readEd25519PublicKey 读取 PKIX PEM 格式的 ed25519 公钥
+func readEd25519PublicKey(keyFile string) (ed25519.PublicKey, error) {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("%s: not a PEM public key", keyFile)
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	pub, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: not an ed25519 key", keyFile)
+	}
+	return pub, nil
+}
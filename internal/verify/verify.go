@@ -0,0 +1,218 @@
+// Package verify 提供 skill 来源的签名校验
+// 支持 git 签名提交/标签（git verify-commit / verify-tag）以及归档的分离签名（ed25519 或 SSH 签名）
+package verify
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Status 校验结果状态
+type Status string
+
+const (
+	StatusVerified Status = "verified" // 签名有效且来自受信任的签名者
+	StatusUnsigned Status = "unsigned" // 未签名
+	StatusInvalid  Status = "invalid"  // 签名无效或签名者不受信任
+	StatusSkipped  Status = "skipped"  // 策略为 off，未校验
+)
+
+// Result 表示一次签名校验的结果
+type Result struct {
+	Status Status `json:"status"`
+	Method string `json:"method,omitempty"` // git-commit、git-tag、ssh、ed25519
+	Signer string `json:"signer,omitempty"` // 签名者描述
+	Commit string `json:"commit,omitempty"` // git 来源的提交 SHA
+	Detail string `json:"detail,omitempty"` // 失败原因
+}
+
+// Verified 判断是否校验通过
+func (r Result) Verified() bool {
+	return r.Status == StatusVerified
+}
+
+// GitCommit 返回仓库 HEAD 的提交 SHA
+func GitCommit(repoDir string) string {
+	out, err := exec.Command("git", "-C", repoDir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// VerifyGit 校验仓库 HEAD 的签名
+// 优先校验指向 HEAD 的签名标签，其次校验 HEAD 提交本身
+// allowedSigners 为 SSH allowed signers 文件，为空时仅依赖用户的 git/gpg 配置
+// allowedSigners 只能固定 SSH 签名者，配置后拒绝 GPG、X.509 等其他格式的签名，
+// 否则用户密钥环中任意密钥的签名都能通过校验
+func VerifyGit(repoDir, allowedSigners string) Result {
+	result := Result{Commit: GitCommit(repoDir)}
+
+	var details []string
+	unpinned := false // 存在无法被 allowedSigners 固定的签名
+	tagsOut, _ := exec.Command("git", "-C", repoDir, "tag", "--points-at", "HEAD").Output()
+	for _, tag := range strings.Fields(string(tagsOut)) {
+		if detail := checkPinnedFormat(repoDir, allowedSigners, "tag", tag); detail != "" {
+			details = append(details, fmt.Sprintf("tag %s: %s", tag, detail))
+			unpinned = true
+			continue
+		}
+		out, err := runGitVerify(repoDir, allowedSigners, "verify-tag", tag)
+		if err == nil {
+			result.Status = StatusVerified
+			result.Method = "git-tag"
+			result.Signer = parseSigner(out)
+			return result
+		}
+		details = append(details, fmt.Sprintf("tag %s: %s", tag, firstLine(out)))
+	}
+
+	if detail := checkPinnedFormat(repoDir, allowedSigners, "commit", "HEAD"); detail != "" {
+		result.Method = "git-commit"
+		result.Status = StatusInvalid
+		result.Detail = strings.Join(append(details, detail), "; ")
+		return result
+	}
+	out, err := runGitVerify(repoDir, allowedSigners, "verify-commit", "HEAD")
+	if err == nil {
+		result.Status = StatusVerified
+		result.Method = "git-commit"
+		result.Signer = parseSigner(out)
+		return result
+	}
+
+	result.Method = "git-commit"
+	if strings.TrimSpace(out) == "" || strings.Contains(strings.ToLower(out), "no signature") {
+		// 标签带有被拒绝的签名时不能报告为未签名
+		result.Status = StatusUnsigned
+		if unpinned {
+			result.Status = StatusInvalid
+		}
+		details = append(details, "commit is not signed")
+	} else {
+		result.Status = StatusInvalid
+		details = append(details, firstLine(out))
+	}
+	result.Detail = strings.Join(details, "; ")
+	return result
+}
+
+// 签名格式
+const (
+	formatSSH     = "ssh"
+	formatOpenPGP = "openpgp"
+	formatX509    = "x509"
+)
+
+// signatureMarkers 签名开头标记及其格式（与 git 识别的标记一致）
+var signatureMarkers = []struct {
+	prefix string
+	format string
+}{
+	{"-----BEGIN PGP SIGNATURE-----", formatOpenPGP},
+	{"-----BEGIN PGP MESSAGE-----", formatOpenPGP},
+	{"-----BEGIN SIGNED MESSAGE-----", formatX509},
+	{sshSignatureHeader, formatSSH},
+}
+
+// markerFormat 返回以签名开头标记起始的行对应的格式，不是标记时为空
+func markerFormat(line string) string {
+	for _, m := range signatureMarkers {
+		if strings.HasPrefix(line, m.prefix) {
+			return m.format
+		}
+	}
+	return ""
+}
+
+// signatureFormat 返回 git 对象签名的格式，未签名时为空
+// 提交的签名位于 gpgsig 头中；标签的签名附在消息末尾，与 git 一样取最后一个签名开头标记
+func signatureFormat(repoDir, objectType, ref string) string {
+	out, err := exec.Command("git", "-C", repoDir, "cat-file", objectType, ref).Output()
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(string(out), "\n")
+
+	if objectType == "commit" {
+		for _, line := range lines {
+			// 空行之后为提交消息，消息中的标记不是签名
+			if line == "" {
+				break
+			}
+			for _, header := range []string{"gpgsig ", "gpgsig-sha256 "} {
+				if value, ok := strings.CutPrefix(line, header); ok {
+					return markerFormat(value)
+				}
+			}
+		}
+		return ""
+	}
+
+	format := ""
+	for _, line := range lines {
+		if f := markerFormat(line); f != "" {
+			format = f
+		}
+	}
+	return format
+}
+
+// checkPinnedFormat 配置了 allowedSigners 时检查签名格式必须为 SSH
+// 返回: 不满足时的失败原因，满足或未签名时为空
+func checkPinnedFormat(repoDir, allowedSigners, objectType, ref string) string {
+	if allowedSigners == "" {
+		return ""
+	}
+	format := signatureFormat(repoDir, objectType, ref)
+	if format == "" || format == formatSSH {
+		return ""
+	}
+	return fmt.Sprintf("%s signature cannot be pinned by verify.allowed-signers, only SSH signatures are accepted", format)
+}
+
+// runGitVerify 执行 git verify-commit / verify-tag，返回合并后的输出
+func runGitVerify(repoDir, allowedSigners, subcommand, ref string) (string, error) {
+	args := []string{"-C", repoDir}
+	if allowedSigners != "" {
+		args = append(args, "-c", "gpg.ssh.allowedSignersFile="+allowedSigners)
+	}
+	args = append(args, subcommand, ref)
+
+	var out bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	return out.String(), err
+}
+
+// parseSigner 从 git/ssh-keygen 输出中提取签名者描述
+func parseSigner(out string) string {
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Good") {
+			return line
+		}
+	}
+	return firstLine(out)
+}
+
+// firstLine 返回第一行非空输出
+func firstLine(out string) string {
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// fileExists 判断文件是否存在
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
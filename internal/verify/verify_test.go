@@ -0,0 +1,301 @@
+package verify
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// requireTools 缺少外部命令时跳过测试
+func requireTools(t *testing.T, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		if _, err := exec.LookPath(name); err != nil {
+			t.Skipf("%s not available", name)
+		}
+	}
+}
+
+// runCmd 执行命令，失败时终止测试，返回去除首尾空白的输出
+func runCmd(t *testing.T, dir, name string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%s %s: %v\n%s", name, strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// newSSHKey 生成 SSH ed25519 密钥，返回私钥路径与公钥内容
+func newSSHKey(t *testing.T, name string) (string, string) {
+	t.Helper()
+	keyFile := filepath.Join(t.TempDir(), name)
+	runCmd(t, "", "ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", name, "-f", keyFile)
+	pub, err := os.ReadFile(keyFile + ".pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyFile, strings.TrimSpace(string(pub))
+}
+
+// writeAllowedSigners 写入仅信任 principal 对应公钥的 allowed signers 文件
+func writeAllowedSigners(t *testing.T, principal, pubKey string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "allowed_signers")
+	if err := os.WriteFile(path, []byte(principal+" "+pubKey+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// newGitRepo 创建包含一个提交的临时仓库，signingKey 非空时使用该 SSH 密钥签名
+func newGitRepo(t *testing.T, signingKey string) string {
+	t.Helper()
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	dir := t.TempDir()
+	runCmd(t, dir, "git", "init", "-q")
+	runCmd(t, dir, "git", "config", "user.name", "Test")
+	runCmd(t, dir, "git", "config", "user.email", "test@example.com")
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: demo\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runCmd(t, dir, "git", "add", "SKILL.md")
+
+	args := []string{"commit", "-q", "-m", "init"}
+	if signingKey != "" {
+		runCmd(t, dir, "git", "config", "gpg.format", "ssh")
+		runCmd(t, dir, "git", "config", "user.signingkey", signingKey)
+		args = append(args, "-S")
+	}
+	runCmd(t, dir, "git", args...)
+	return dir
+}
+
+// fakePGPSignedCommit 以 HEAD 的树伪造一个带 PGP gpgsig 头的提交并设为 HEAD
+// 格式检查先于签名校验，签名内容本身不需要有效
+func fakePGPSignedCommit(t *testing.T, dir string) {
+	t.Helper()
+	tree := runCmd(t, dir, "git", "rev-parse", "HEAD^{tree}")
+	commit := "tree " + tree + "\n" +
+		"author Test <test@example.com> 1700000000 +0000\n" +
+		"committer Test <test@example.com> 1700000000 +0000\n" +
+		"gpgsig -----BEGIN PGP SIGNATURE-----\n" +
+		" \n" +
+		" iQEzBAABCAAdFiEEAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA\n" +
+		" -----END PGP SIGNATURE-----\n" +
+		"\n" +
+		"pgp signed\n"
+	objectFile := filepath.Join(t.TempDir(), "commit")
+	if err := os.WriteFile(objectFile, []byte(commit), 0644); err != nil {
+		t.Fatal(err)
+	}
+	id := runCmd(t, dir, "git", "hash-object", "-t", "commit", "-w", objectFile)
+	runCmd(t, dir, "git", "update-ref", "HEAD", id)
+}
+
+// fakePGPSignedTag 伪造一个指向 HEAD、消息末尾附 PGP 签名的附注标签
+func fakePGPSignedTag(t *testing.T, dir, name string) {
+	t.Helper()
+	tag := "object " + runCmd(t, dir, "git", "rev-parse", "HEAD") + "\n" +
+		"type commit\n" +
+		"tag " + name + "\n" +
+		"tagger Test <test@example.com> 1700000000 +0000\n" +
+		"\n" +
+		"release\n" +
+		"-----BEGIN PGP SIGNATURE-----\n" +
+		"\n" +
+		"iQEzBAABCAAdFiEEAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA\n" +
+		"-----END PGP SIGNATURE-----\n"
+	objectFile := filepath.Join(t.TempDir(), "tag")
+	if err := os.WriteFile(objectFile, []byte(tag), 0644); err != nil {
+		t.Fatal(err)
+	}
+	id := runCmd(t, dir, "git", "hash-object", "-t", "tag", "-w", objectFile)
+	runCmd(t, dir, "git", "update-ref", "refs/tags/"+name, id)
+}
+
+func TestVerifyGit(t *testing.T) {
+	requireTools(t, "git", "ssh-keygen")
+	trustedKey, trustedPub := newSSHKey(t, "trusted")
+	otherKey, _ := newSSHKey(t, "other")
+	allowed := writeAllowedSigners(t, "test@example.com", trustedPub)
+
+	tests := []struct {
+		name       string
+		setup      func(t *testing.T) string
+		allowed    string
+		want       Status
+		wantDetail string
+	}{
+		{
+			name:  "unsigned commit",
+			setup: func(t *testing.T) string { return newGitRepo(t, "") },
+			want:  StatusUnsigned,
+		},
+		{
+			name:    "trusted SSH signer",
+			setup:   func(t *testing.T) string { return newGitRepo(t, trustedKey) },
+			allowed: allowed,
+			want:    StatusVerified,
+		},
+		{
+			name:    "SSH signer not in allowed signers",
+			setup:   func(t *testing.T) string { return newGitRepo(t, otherKey) },
+			allowed: allowed,
+			want:    StatusInvalid,
+		},
+		{
+			name: "PGP signature with allowed signers set",
+			setup: func(t *testing.T) string {
+				dir := newGitRepo(t, "")
+				fakePGPSignedCommit(t, dir)
+				return dir
+			},
+			allowed:    allowed,
+			want:       StatusInvalid,
+			wantDetail: "openpgp signature cannot be pinned",
+		},
+		{
+			name: "PGP signed tag with allowed signers set",
+			setup: func(t *testing.T) string {
+				dir := newGitRepo(t, "")
+				fakePGPSignedTag(t, dir, "v1")
+				return dir
+			},
+			allowed:    allowed,
+			want:       StatusInvalid,
+			wantDetail: "tag v1: openpgp signature cannot be pinned",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := tt.setup(t)
+			got := VerifyGit(dir, tt.allowed)
+			if got.Status != tt.want {
+				t.Fatalf("VerifyGit() status = %s, want %s (detail: %s)", got.Status, tt.want, got.Detail)
+			}
+			if !strings.Contains(got.Detail, tt.wantDetail) {
+				t.Errorf("VerifyGit() detail = %q, want it to contain %q", got.Detail, tt.wantDetail)
+			}
+			if got.Commit == "" {
+				t.Error("VerifyGit() did not record the commit")
+			}
+		})
+	}
+}
+
+func TestSignatureFormat(t *testing.T) {
+	requireTools(t, "git", "ssh-keygen")
+	key, _ := newSSHKey(t, "trusted")
+
+	sshRepo := newGitRepo(t, key)
+	if got := signatureFormat(sshRepo, "commit", "HEAD"); got != formatSSH {
+		t.Errorf("signatureFormat(ssh commit) = %q, want %q", got, formatSSH)
+	}
+	runCmd(t, sshRepo, "git", "tag", "-s", "-m", "release", "v1")
+	if got := signatureFormat(sshRepo, "tag", "v1"); got != formatSSH {
+		t.Errorf("signatureFormat(ssh tag) = %q, want %q", got, formatSSH)
+	}
+
+	// 提交消息中的标记不是签名
+	plainRepo := newGitRepo(t, "")
+	if err := os.WriteFile(filepath.Join(plainRepo, "msg"), []byte("-----BEGIN PGP SIGNATURE-----\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runCmd(t, plainRepo, "git", "commit", "-q", "--allow-empty", "-F", "msg")
+	if got := signatureFormat(plainRepo, "commit", "HEAD"); got != "" {
+		t.Errorf("signatureFormat(unsigned commit) = %q, want none", got)
+	}
+}
+
+// writeEd25519Keys 生成 PKCS#8 私钥与 PKIX 公钥 PEM 文件
+func writeEd25519Keys(t *testing.T) (string, string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	privFile := filepath.Join(dir, "key.pem")
+	pubFile := filepath.Join(dir, "key.pub.pem")
+	if err := os.WriteFile(privFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pubFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return privFile, pubFile
+}
+
+func TestVerifyArchive(t *testing.T) {
+	requireTools(t, "ssh-keygen")
+	edKey, edPub := writeEd25519Keys(t)
+	_, otherPub := writeEd25519Keys(t)
+	sshKey, sshPub := newSSHKey(t, "trusted")
+	_, otherSSHPub := newSSHKey(t, "other")
+	allowed := writeAllowedSigners(t, "release", sshPub)
+	otherAllowed := writeAllowedSigners(t, "release", otherSSHPub)
+
+	tests := []struct {
+		name       string
+		key        string // 为空表示不签名
+		tamper     bool
+		allowed    string
+		publicKeys []string
+		want       Status
+	}{
+		{name: "unsigned", want: StatusUnsigned},
+		{name: "ed25519 signature", key: edKey, publicKeys: []string{edPub}, want: StatusVerified},
+		{name: "ed25519 wrong key", key: edKey, publicKeys: []string{otherPub}, want: StatusInvalid},
+		{name: "ed25519 tampered archive", key: edKey, tamper: true, publicKeys: []string{edPub}, want: StatusInvalid},
+		{name: "ed25519 without public keys", key: edKey, want: StatusInvalid},
+		{name: "SSH signature", key: sshKey, allowed: allowed, want: StatusVerified},
+		{name: "SSH signer not in allowed signers", key: sshKey, allowed: otherAllowed, want: StatusInvalid},
+		{name: "SSH tampered archive", key: sshKey, tamper: true, allowed: allowed, want: StatusInvalid},
+		{name: "SSH without allowed signers", key: sshKey, want: StatusInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := filepath.Join(t.TempDir(), "demo.zip")
+			if err := os.WriteFile(archive, []byte("archive content"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			sigPath := archive + SignatureSuffix
+			if tt.key != "" {
+				var err error
+				if sigPath, _, err = SignArchive(archive, tt.key); err != nil {
+					t.Fatalf("SignArchive() error = %v", err)
+				}
+			}
+			if tt.tamper {
+				if err := os.WriteFile(archive, []byte("tampered content"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got := VerifyArchive(archive, sigPath, tt.allowed, tt.publicKeys)
+			if got.Status != tt.want {
+				t.Errorf("VerifyArchive() status = %s, want %s (detail: %s)", got.Status, tt.want, got.Detail)
+			}
+		})
+	}
+}
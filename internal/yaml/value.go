@@ -0,0 +1,50 @@
+package yaml
+
+import "strings"
+
+// String 读取映射中的字符串字段，不存在或类型不符时返回空
+func String(m map[string]any, key string) string {
+	if m == nil {
+		return ""
+	}
+	s, _ := m[key].(string)
+	return strings.TrimSpace(s)
+}
+
+// Map 读取映射中的子映射，不存在或类型不符时返回 nil
+func Map(m map[string]any, key string) map[string]any {
+	if m == nil {
+		return nil
+	}
+	sub, _ := m[key].(map[string]any)
+	return sub
+}
+
+// StringSlice 将值解释为字符串列表
+// 支持序列（[a, b] 或块序列）以及逗号/空格分隔的单个字符串
+func StringSlice(v any) []string {
+	var result []string
+	switch val := v.(type) {
+	case []any:
+		for _, item := range val {
+			if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+				result = append(result, strings.TrimSpace(s))
+			}
+		}
+	case string:
+		for _, s := range strings.FieldsFunc(val, func(r rune) bool { return r == ',' || r == ' ' }) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// Bool 将值解释为布尔值，支持 true/false、yes/no、on/off
+func Bool(v any) bool {
+	s, _ := v.(string)
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "yes", "on", "y":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,492 @@
+// Package yaml 提供 SkillSync 所需的 YAML 子集解析
+// 支持范围：块映射、块序列（含序列内映射）、流式序列 [a, b]、流式映射 {k: v}、
+// 单/双引号字符串、块标量（| 与 >）以及折行的普通标量。
+// 所有标量均以 string 返回，由调用方按需解释（如布尔值）。
+package yaml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// line 表示预处理后的一行
+type line struct {
+	num     int    // 原始行号（从 1 开始）
+	indent  int    // 缩进空格数
+	content string // 去除缩进与注释后的内容
+	raw     string // 原始行（用于块标量）
+}
+
+// parser 保存解析状态
+type parser struct {
+	lines []line
+	pos   int
+}
+
+// Parse 解析 YAML 文本
+// 返回: map[string]any、[]any、string 或 nil（空文档）
+func Parse(data []byte) (any, error) {
+	p := &parser{}
+	for i, raw := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(raw, "\t") {
+			return nil, fmt.Errorf("yaml: line %d: tabs are not allowed for indentation", i+1)
+		}
+		content := stripComment(raw)
+		trimmed := strings.TrimSpace(content)
+		if trimmed == "---" || trimmed == "..." {
+			continue
+		}
+		p.lines = append(p.lines, line{
+			num:     i + 1,
+			indent:  len(content) - len(strings.TrimLeft(content, " ")),
+			content: trimmed,
+			raw:     raw,
+		})
+	}
+
+	p.skipBlank()
+	if p.eof() {
+		return nil, nil
+	}
+	v, err := p.parseBlock(p.cur().indent)
+	if err != nil {
+		return nil, err
+	}
+	// 顶层块结束后不允许残留内容（如序列后接同缩进的映射）
+	p.skipBlank()
+	if !p.eof() {
+		return nil, fmt.Errorf("yaml: line %d: unexpected content", p.cur().num)
+	}
+	return v, nil
+}
+
+// ParseMap 解析 YAML 文本并要求顶层为映射
+func ParseMap(data []byte) (map[string]any, error) {
+	v, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return map[string]any{}, nil
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("yaml: top-level value is not a mapping")
+	}
+	return m, nil
+}
+
+func (p *parser) eof() bool { return p.pos >= len(p.lines) }
+
+func (p *parser) cur() line { return p.lines[p.pos] }
+
+// skipBlank 跳过空行与纯注释行
+func (p *parser) skipBlank() {
+	for !p.eof() && p.cur().content == "" {
+		p.pos++
+	}
+}
+
+// parseBlock 根据当前行判断解析序列还是映射
+func (p *parser) parseBlock(indent int) (any, error) {
+	if isSeqItem(p.cur().content) {
+		return p.parseSequence(indent)
+	}
+	if _, _, ok := splitKey(p.cur().content); ok {
+		return p.parseMapping(indent)
+	}
+	// 单个标量文档
+	l := p.cur()
+	p.pos++
+	return parseScalar(l.content, l.num)
+}
+
+// parseMapping 解析指定缩进的块映射
+func (p *parser) parseMapping(indent int) (map[string]any, error) {
+	result := map[string]any{}
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return result, nil
+		}
+		l := p.cur()
+		if l.indent < indent {
+			return result, nil
+		}
+		if l.indent > indent {
+			return nil, fmt.Errorf("yaml: line %d: unexpected indentation", l.num)
+		}
+		if isSeqItem(l.content) {
+			return result, nil
+		}
+
+		key, rest, ok := splitKey(l.content)
+		if !ok {
+			return nil, fmt.Errorf("yaml: line %d: expected key: value", l.num)
+		}
+		p.pos++
+
+		value, err := p.parseValue(rest, indent, l.num)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+}
+
+// parseValue 解析映射值或序列项中冒号后的部分
+// parentIndent 为所属键的缩进，用于判断子块与折行
+func (p *parser) parseValue(rest string, parentIndent, num int) (any, error) {
+	switch {
+	case rest == "":
+		p.skipBlank()
+		if p.eof() {
+			return nil, nil
+		}
+		next := p.cur()
+		if next.indent > parentIndent {
+			return p.parseBlock(next.indent)
+		}
+		// 允许序列与键同缩进：key:\n- a
+		if next.indent == parentIndent && isSeqItem(next.content) {
+			return p.parseSequence(parentIndent)
+		}
+		return nil, nil
+	case strings.HasPrefix(rest, "|") || strings.HasPrefix(rest, ">"):
+		return p.parseBlockScalar(rest, parentIndent), nil
+	case strings.HasPrefix(rest, "[") || strings.HasPrefix(rest, "{"):
+		v, remaining, err := parseFlow(rest, num)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(remaining) != "" {
+			return nil, fmt.Errorf("yaml: line %d: unexpected content after flow collection", num)
+		}
+		return v, nil
+	case rest[0] == '"' || rest[0] == '\'':
+		return parseScalar(rest, num)
+	default:
+		// 普通标量：合并后续更深缩进的折行，折行中不允许出现映射
+		parts := []string{rest}
+		for !p.eof() {
+			next := p.cur()
+			if next.content == "" || next.indent <= parentIndent {
+				break
+			}
+			if _, _, ok := splitKey(next.content); ok {
+				return nil, fmt.Errorf("yaml: line %d: unexpected indentation", next.num)
+			}
+			parts = append(parts, next.content)
+			p.pos++
+		}
+		return parseScalar(strings.Join(parts, " "), num)
+	}
+}
+
+// parseSequence 解析指定缩进的块序列
+func (p *parser) parseSequence(indent int) ([]any, error) {
+	result := []any{}
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return result, nil
+		}
+		l := p.cur()
+		if l.indent != indent || !isSeqItem(l.content) {
+			if l.indent > indent {
+				return nil, fmt.Errorf("yaml: line %d: unexpected indentation", l.num)
+			}
+			return result, nil
+		}
+
+		rest := strings.TrimSpace(strings.TrimPrefix(l.content, "-"))
+		if rest == "" {
+			p.pos++
+			value, err := p.parseValue("", indent, l.num)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+			continue
+		}
+
+		// 序列项本身是映射或序列："- key: value" / "- - a"，将本行改写为更深缩进的行
+		if _, _, ok := splitKey(rest); ok || isSeqItem(rest) {
+			offset := len(l.content) - len(rest)
+			p.lines[p.pos] = line{num: l.num, indent: l.indent + offset, content: rest, raw: l.raw}
+			value, err := p.parseBlock(l.indent + offset)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+			continue
+		}
+
+		p.pos++
+		value, err := p.parseValue(rest, indent, l.num)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+}
+
+// parseBlockScalar 解析 | 或 > 块标量
+func (p *parser) parseBlockScalar(header string, parentIndent int) string {
+	folded := strings.HasPrefix(header, ">")
+	keep := strings.Contains(header, "+")
+	strip := strings.Contains(header, "-")
+
+	var lines []string
+	blockIndent := -1
+	for !p.eof() {
+		l := p.cur()
+		if strings.TrimSpace(l.raw) == "" {
+			lines = append(lines, "")
+			p.pos++
+			continue
+		}
+		rawIndent := len(l.raw) - len(strings.TrimLeft(l.raw, " "))
+		if rawIndent <= parentIndent {
+			break
+		}
+		if blockIndent < 0 {
+			blockIndent = rawIndent
+		}
+		if rawIndent < blockIndent {
+			break
+		}
+		lines = append(lines, l.raw[blockIndent:])
+		p.pos++
+	}
+
+	// 尾部空行按 chomping 规则处理
+	trailing := 0
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+		trailing++
+	}
+
+	var text string
+	if folded {
+		var b strings.Builder
+		for i, s := range lines {
+			// 空行折为换行，相邻的非空行以空格连接
+			switch {
+			case s == "":
+				b.WriteString("\n")
+			case i > 0 && lines[i-1] != "":
+				b.WriteString(" ")
+			}
+			b.WriteString(s)
+		}
+		text = b.String()
+	} else {
+		text = strings.Join(lines, "\n")
+	}
+
+	switch {
+	case strip || text == "":
+		return text
+	case keep:
+		return text + "\n" + strings.Repeat("\n", trailing)
+	default:
+		return text + "\n"
+	}
+}
+
+// parseFlow 解析流式集合（[a, b] 或 {k: v}），返回剩余未解析文本
+func parseFlow(s string, num int) (any, string, error) {
+	s = strings.TrimLeft(s, " ")
+	if s == "" {
+		return nil, "", fmt.Errorf("yaml: line %d: unexpected end of flow collection", num)
+	}
+
+	switch s[0] {
+	case '[':
+		result := []any{}
+		s = strings.TrimLeft(s[1:], " ")
+		for {
+			if s == "" {
+				return nil, "", fmt.Errorf("yaml: line %d: unterminated flow sequence", num)
+			}
+			if s[0] == ']' {
+				return result, s[1:], nil
+			}
+			v, remaining, err := parseFlow(s, num)
+			if err != nil {
+				return nil, "", err
+			}
+			if remaining == s {
+				return nil, "", fmt.Errorf("yaml: line %d: invalid flow sequence", num)
+			}
+			result = append(result, v)
+			s = strings.TrimLeft(remaining, " ")
+			if strings.HasPrefix(s, ",") {
+				s = strings.TrimLeft(s[1:], " ")
+			}
+		}
+	case '{':
+		result := map[string]any{}
+		s = strings.TrimLeft(s[1:], " ")
+		for {
+			if s == "" {
+				return nil, "", fmt.Errorf("yaml: line %d: unterminated flow mapping", num)
+			}
+			if s[0] == '}' {
+				return result, s[1:], nil
+			}
+			keyValue, remaining, err := parseFlowScalar(s, ":", num)
+			if err != nil {
+				return nil, "", err
+			}
+			key, _ := keyValue.(string)
+			s = strings.TrimLeft(strings.TrimPrefix(strings.TrimLeft(remaining, " "), ":"), " ")
+			v, remaining, err := parseFlow(s, num)
+			if err != nil {
+				return nil, "", err
+			}
+			if remaining == s && !strings.HasPrefix(s, ",") {
+				return nil, "", fmt.Errorf("yaml: line %d: invalid flow mapping", num)
+			}
+			result[key] = v
+			s = strings.TrimLeft(remaining, " ")
+			if strings.HasPrefix(s, ",") {
+				s = strings.TrimLeft(s[1:], " ")
+			}
+		}
+	default:
+		return parseFlowScalar(s, "", num)
+	}
+}
+
+// parseFlowScalar 解析流式集合中的单个标量
+// extraStop 为额外的终止字符（映射键使用 ":"）
+func parseFlowScalar(s, extraStop string, num int) (any, string, error) {
+	if s[0] == '"' || s[0] == '\'' {
+		end := findClosingQuote(s)
+		if end < 0 {
+			return nil, "", fmt.Errorf("yaml: line %d: unterminated quoted string", num)
+		}
+		v, err := parseScalar(s[:end+1], num)
+		return v, s[end+1:], err
+	}
+	stops := ",]}" + extraStop
+	end := strings.IndexAny(s, stops)
+	if end < 0 {
+		end = len(s)
+	}
+	return strings.TrimSpace(s[:end]), s[end:], nil
+}
+
+// parseScalar 解析单个标量（处理引号）
+func parseScalar(s string, num int) (any, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "~" || s == "null" {
+		return nil, nil
+	}
+	switch s[0] {
+	case '"':
+		if findClosingQuote(s) != len(s)-1 {
+			return nil, fmt.Errorf("yaml: line %d: invalid double-quoted string", num)
+		}
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			// 回退：去除外层引号
+			return s[1 : len(s)-1], nil
+		}
+		return v, nil
+	case '\'':
+		if findClosingQuote(s) != len(s)-1 {
+			return nil, fmt.Errorf("yaml: line %d: invalid single-quoted string", num)
+		}
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	}
+	return s, nil
+}
+
+// findClosingQuote 返回与首字符引号匹配的结束引号下标，未找到返回 -1
+func findClosingQuote(s string) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case quote == '\'' && s[i] == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// isSeqItem 判断内容是否为块序列项
+func isSeqItem(content string) bool {
+	return content == "-" || strings.HasPrefix(content, "- ")
+}
+
+// splitKey 将 "key: value" 拆分为键与值
+func splitKey(content string) (string, string, bool) {
+	if content == "" {
+		return "", "", false
+	}
+
+	// 带引号的键
+	if content[0] == '"' || content[0] == '\'' {
+		end := findClosingQuote(content)
+		if end < 0 {
+			return "", "", false
+		}
+		rest := content[end+1:]
+		if rest != ":" && !strings.HasPrefix(rest, ": ") {
+			return "", "", false
+		}
+		key, err := parseScalar(content[:end+1], 0)
+		if err != nil {
+			return "", "", false
+		}
+		keyStr, _ := key.(string)
+		return keyStr, strings.TrimSpace(rest[1:]), true
+	}
+
+	if content[0] == '[' || content[0] == '{' || isSeqItem(content) {
+		return "", "", false
+	}
+
+	if idx := strings.Index(content, ": "); idx > 0 {
+		return strings.TrimSpace(content[:idx]), strings.TrimSpace(content[idx+2:]), true
+	}
+	if strings.HasSuffix(content, ":") && len(content) > 1 {
+		return strings.TrimSpace(content[:len(content)-1]), "", true
+	}
+	return "", "", false
+}
+
+// stripComment 去除行内注释（忽略引号内的 #）
+// 引号仅在词首出现时视为字符串开始，避免 "don't" 之类的撇号干扰
+func stripComment(raw string) string {
+	var quote byte
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		if quote != 0 {
+			switch {
+			case c == '\\' && quote == '"':
+				i++
+			case c == '\'' && quote == '\'' && i+1 < len(raw) && raw[i+1] == '\'':
+				i++
+			case c == quote:
+				quote = 0
+			}
+			continue
+		}
+		switch {
+		case (c == '\'' || c == '"') && (i == 0 || strings.IndexByte(" [{,:-", raw[i-1]) >= 0):
+			quote = c
+		case c == '#' && (i == 0 || raw[i-1] == ' '):
+			return strings.TrimRight(raw[:i], " ")
+		}
+	}
+	return strings.TrimRight(raw, " ")
+}
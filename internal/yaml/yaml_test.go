@@ -0,0 +1,215 @@
+package yaml
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want any
+	}{
+		{"empty", "", nil},
+		{"only comments", "# comment\n\n# another\n", nil},
+		{"document markers", "---\nname: demo\n...\n", map[string]any{"name": "demo"}},
+		{"scalar document", "hello", "hello"},
+		{
+			name: "flat mapping",
+			in:   "name: demo\ndescription: A skill\n",
+			want: map[string]any{"name": "demo", "description": "A skill"},
+		},
+		{
+			name: "empty value",
+			in:   "name:\nversion: 1.0\n",
+			want: map[string]any{"name": nil, "version": "1.0"},
+		},
+		{"null values", "a: ~\nb: null\n", map[string]any{"a": nil, "b": nil}},
+		{
+			name: "nested mappings",
+			in:   "metadata:\n  author: me\n  tags:\n    primary: go\n",
+			want: map[string]any{"metadata": map[string]any{"author": "me", "tags": map[string]any{"primary": "go"}}},
+		},
+		{
+			name: "block sequence",
+			in:   "tools:\n  - claude\n  - codex\n",
+			want: map[string]any{"tools": []any{"claude", "codex"}},
+		},
+		{
+			name: "block sequence at key indent",
+			in:   "tools:\n- claude\n- codex\n",
+			want: map[string]any{"tools": []any{"claude", "codex"}},
+		},
+		{
+			name: "sequence of mappings",
+			in:   "deps:\n  - source: a/b\n    ref: main\n  - source: c/d\n",
+			want: map[string]any{"deps": []any{
+				map[string]any{"source": "a/b", "ref": "main"},
+				map[string]any{"source": "c/d"},
+			}},
+		},
+		{"nested sequences", "- - a\n  - b\n- c\n", []any{[]any{"a", "b"}, "c"}},
+		{"top-level sequence", "- a\n- b\n", []any{"a", "b"}},
+		{
+			name: "flow sequence",
+			in:   "tools: [claude, codex, \"gemini\"]\n",
+			want: map[string]any{"tools": []any{"claude", "codex", "gemini"}},
+		},
+		{"empty flow sequence", "tools: []\n", map[string]any{"tools": []any{}}},
+		{
+			name: "flow mapping",
+			in:   "owner: {name: me, email: 'me@example.com'}\n",
+			want: map[string]any{"owner": map[string]any{"name": "me", "email": "me@example.com"}},
+		},
+		{
+			name: "nested flow collections",
+			in:   "x: [a, {b: c}, [d, e]]\n",
+			want: map[string]any{"x": []any{"a", map[string]any{"b": "c"}, []any{"d", "e"}}},
+		},
+		{
+			name: "double-quoted scalar",
+			in:   `description: "foo: bar # not a comment \"quoted\"\n"` + "\n",
+			want: map[string]any{"description": "foo: bar # not a comment \"quoted\"\n"},
+		},
+		{
+			name: "single-quoted scalar",
+			in:   "description: 'it''s here # too'\n",
+			want: map[string]any{"description": "it's here # too"},
+		},
+		{
+			name: "quoted key",
+			in:   "\"a: b\": value\n'c': d\n",
+			want: map[string]any{"a: b": "value", "c": "d"},
+		},
+		{
+			name: "comments",
+			in:   "# header\nname: demo # trailing\n  # indented comment\nurl: http://x#frag\n",
+			want: map[string]any{"name": "demo", "url": "http://x#frag"},
+		},
+		{
+			name: "apostrophe does not start a quote",
+			in:   "description: don't stop # comment\n",
+			want: map[string]any{"description": "don't stop"},
+		},
+		{
+			name: "folded plain scalar",
+			in:   "description: first line\n  second line\nname: demo\n",
+			want: map[string]any{"description": "first line second line", "name": "demo"},
+		},
+		{
+			name: "literal block scalar",
+			in:   "body: |\n  line one\n  line two\nname: demo\n",
+			want: map[string]any{"body": "line one\nline two\n", "name": "demo"},
+		},
+		{
+			name: "folded block scalar",
+			in:   "body: >\n  line one\n  line two\n\n  para two\n",
+			want: map[string]any{"body": "line one line two\npara two\n"},
+		},
+		{
+			name: "strip chomping",
+			in:   "body: |-\n  text\n\nname: demo\n",
+			want: map[string]any{"body": "text", "name": "demo"},
+		},
+		{
+			name: "keep chomping",
+			in:   "body: |+\n  text\n\n",
+			want: map[string]any{"body": "text\n\n\n"},
+		},
+		{
+			name: "windows line endings",
+			in:   "name: demo\r\ntools:\r\n  - a\r\n",
+			want: map[string]any{"name": "demo", "tools": []any{"a"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.in))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string // 错误信息片段
+	}{
+		{"tab indentation", "a:\n\tb: c\n", "line 2: tabs are not allowed"},
+		{"unexpected indentation", "a: b\n  c: d\n", "line 2: unexpected indentation"},
+		{"missing colon", "a: b\nplain\n", "line 2: expected key: value"},
+		{"unterminated flow sequence", "a: [b, c\n", "unterminated flow sequence"},
+		{"unterminated flow mapping", "a: {b: c\n", "unterminated flow mapping"},
+		{"unterminated quoted string in flow", "a: [\"b, c]\n", "unterminated quoted string"},
+		{"content after flow collection", "a: [b] c\n", "unexpected content after flow collection"},
+		{"invalid double-quoted scalar", "a: \"b\" c\n", "invalid double-quoted string"},
+		{"invalid single-quoted scalar", "a: 'b' c\n", "invalid single-quoted string"},
+		{"mapping in plain scalar continuation", "a:\n  - b\n    c: d\n", "line 3: unexpected indentation"},
+		{"mapping after top-level sequence", "- a\nb: c\n", "line 2: unexpected content"},
+		{"sequence after top-level mapping", "a: b\n- c\n", "line 2: unexpected content"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.in))
+			if err == nil {
+				t.Fatalf("Parse() error = nil, want %q", tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse() error = %q, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMap(t *testing.T) {
+	m, err := ParseMap(nil)
+	if err != nil || len(m) != 0 {
+		t.Errorf("ParseMap(nil) = %v, %v, want empty map", m, err)
+	}
+	if _, err := ParseMap([]byte("- a\n")); err == nil {
+		t.Error("ParseMap(sequence) error = nil, want error")
+	}
+}
+
+func TestValueHelpers(t *testing.T) {
+	m := map[string]any{
+		"name":  "  demo ",
+		"meta":  map[string]any{"k": "v"},
+		"list":  []any{"a", " b ", "", nil},
+		"csv":   "a, b c",
+		"yes":   "Yes",
+		"false": "false",
+	}
+
+	if got := String(m, "name"); got != "demo" {
+		t.Errorf("String() = %q, want %q", got, "demo")
+	}
+	if got := String(m, "meta"); got != "" {
+		t.Errorf("String(non-string) = %q, want empty", got)
+	}
+	if got := Map(m, "meta"); !reflect.DeepEqual(got, map[string]any{"k": "v"}) {
+		t.Errorf("Map() = %v", got)
+	}
+	if got := Map(m, "name"); got != nil {
+		t.Errorf("Map(non-map) = %v, want nil", got)
+	}
+	if got := StringSlice(m["list"]); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("StringSlice(list) = %v", got)
+	}
+	if got := StringSlice(m["csv"]); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("StringSlice(csv) = %v", got)
+	}
+	if !Bool(m["yes"]) || Bool(m["false"]) || Bool(nil) {
+		t.Error("Bool() returned unexpected result")
+	}
+}
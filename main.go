@@ -1,5 +1,7 @@
 package main
 
+//go:generate go run ./tools/gendocs
+
 import "github.com/AlfonsSkills/SkillSync/cmd"
 
 func main() {
@@ -0,0 +1,100 @@
+// Command gendocs 根据工具注册表重新生成 README 中的支持工具表格
+//
+// 表格位于 <!-- tools:<category> --> 与 <!-- /tools:<category> --> 标记之间
+// 由仓库根目录 main.go 中的 go:generate 指令调用：
+//
+//	go generate .
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/AlfonsSkills/SkillSync/internal/target"
+)
+
+// readme 需要更新的 README 及其表头
+type readme struct {
+	path   string
+	header [3]string
+}
+
+var readmes = []readme{
+	{"README.md", [3]string{"Tool", "Skills Directory", "Flag"}},
+	{"README_CN.md", [3]string{"工具", "Skills 目录", "参数"}},
+}
+
+// categories 生成表格的工具分类
+var categories = []string{target.CategoryTerminal, target.CategoryIDE, target.CategoryShared}
+
+func main() {
+	// 文档展示默认路径，不受本机环境变量影响
+	for _, name := range target.DirEnvVars {
+		os.Unsetenv(name)
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		fail(err)
+	}
+
+	for _, r := range readmes {
+		data, err := os.ReadFile(r.path)
+		if err != nil {
+			fail(err)
+		}
+		content := string(data)
+		for _, category := range categories {
+			table, err := renderTable(category, r.header, homeDir)
+			if err != nil {
+				fail(err)
+			}
+			content, err = replaceBlock(content, category, table)
+			if err != nil {
+				fail(fmt.Errorf("%s: %w", r.path, err))
+			}
+		}
+		if err := os.WriteFile(r.path, []byte(content), 0644); err != nil {
+			fail(err)
+		}
+		fmt.Printf("✓ %s\n", r.path)
+	}
+}
+
+// renderTable 生成指定分类的 Markdown 表格
+func renderTable(category string, header [3]string, homeDir string) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "| %s | %s | %s |\n", header[0], header[1], header[2])
+	b.WriteString("|------|-----------------|------|\n")
+	for _, info := range target.ToolsInCategory(category) {
+		p, err := target.GetProvider(info.Type)
+		if err != nil {
+			return "", err
+		}
+		dir, err := p.GlobalInstallDir()
+		if err != nil {
+			return "", err
+		}
+		if rel, err := filepath.Rel(homeDir, dir); err == nil && !strings.HasPrefix(rel, "..") {
+			dir = "~/" + filepath.ToSlash(rel)
+		}
+		fmt.Fprintf(&b, "| %s | `%s/` | `-t %s` |\n", p.DisplayName(), dir, info.Type)
+	}
+	return b.String(), nil
+}
+
+// replaceBlock 替换标记之间的内容
+func replaceBlock(content, category, table string) (string, error) {
+	re := regexp.MustCompile(`(?s)(<!-- tools:` + category + ` -->\n).*?(<!-- /tools:` + category + ` -->)`)
+	if !re.MatchString(content) {
+		return "", fmt.Errorf("missing <!-- tools:%s --> markers", category)
+	}
+	return re.ReplaceAllLiteralString(content, "<!-- tools:"+category+" -->\n"+table+"<!-- /tools:"+category+" -->"), nil
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "gendocs:", err)
+	os.Exit(1)
+}